@@ -0,0 +1,174 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+)
+
+var AgentsApi = &Endpoint{`agents`, []ApiVersion{{7, `20.1.0`, ``}}}
+
+const agentsPath = `/api/agents`
+
+type Agent struct {
+	Uuid             string           `json:"uuid"`
+	Hostname         string           `json:"hostname"`
+	IpAddress        string           `json:"ip_address"`
+	Sandbox          string           `json:"sandbox"`
+	OperatingSystem  string           `json:"operating_system"`
+	FreeSpace        interface{}      `json:"free_space"`
+	AgentConfigState string           `json:"agent_config_state"`
+	AgentState       string           `json:"agent_state"`
+	BuildState       string           `json:"build_state"`
+	Resources        []string         `json:"resources"`
+	Environments     []AgentEnvRef    `json:"environments"`
+	BuildDetails     *AgentBuildState `json:"build_details,omitempty"`
+}
+
+func (a *Agent) IsEnabled() bool {
+	return `Enabled` == a.AgentConfigState
+}
+
+// Returns the names of the environments the agent belongs to
+func (a *Agent) EnvironmentNames() []string {
+	names := make([]string, 0, len(a.Environments))
+	for _, e := range a.Environments {
+		names = append(names, e.Name)
+	}
+	return names
+}
+
+// Selects the agents matching any of the given UUIDs, hostnames, or
+// hostname globs (e.g., `build-*`), in the order listed. Fails if a pattern
+// matches no agent.
+func MatchAgents(agents []*Agent, patterns []string) ([]*Agent, error) {
+	var matched []*Agent
+	seen := make(map[string]bool)
+
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ``); err != nil {
+			return nil, fmt.Errorf(`Invalid hostname pattern %q: %v`, pattern, err)
+		}
+
+		found := false
+
+		for _, a := range agents {
+			if ok, _ := path.Match(pattern, a.Hostname); !ok && pattern != a.Uuid {
+				continue
+			}
+
+			found = true
+
+			if !seen[a.Uuid] {
+				seen[a.Uuid] = true
+				matched = append(matched, a)
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf(`No agent matches %q`, pattern)
+		}
+	}
+
+	return matched, nil
+}
+
+// Returns the UUIDs of the given agents
+func AgentUuids(agents []*Agent) []string {
+	uuids := make([]string, 0, len(agents))
+	for _, a := range agents {
+		uuids = append(uuids, a.Uuid)
+	}
+	return uuids
+}
+
+// An environment association; Origin tells whether it was configured in
+// GoCD or in a config repo
+type AgentEnvRef struct {
+	Name   string `json:"name"`
+	Origin struct {
+		Type string `json:"type"`
+	} `json:"origin"`
+}
+
+// The job an agent is currently building
+type AgentBuildState struct {
+	PipelineName string `json:"pipeline_name"`
+	StageName    string `json:"stage_name"`
+	JobName      string `json:"job_name"`
+}
+
+// Changes to apply to an agent; empty fields are left unchanged
+type AgentUpdate struct {
+	Hostname         string   `json:"hostname,omitempty"`
+	AgentConfigState string   `json:"agent_config_state,omitempty"`
+	Resources        []string `json:"resources,omitempty"`
+	Environments     []string `json:"environments,omitempty"`
+}
+
+// Changes to apply to several agents at once; empty fields are left unchanged
+type AgentBulkUpdate struct {
+	Uuids            []string            `json:"uuids"`
+	AgentConfigState string              `json:"agent_config_state,omitempty"`
+	Operations       *AgentBulkOperation `json:"operations,omitempty"`
+}
+
+type AgentBulkOperation struct {
+	Environments *AddRemove `json:"environments,omitempty"`
+	Resources    *AddRemove `json:"resources,omitempty"`
+}
+
+// Names to add to and remove from a collection in a PATCH request
+type AddRemove struct {
+	Add    []string `json:"add,omitempty"`
+	Remove []string `json:"remove,omitempty"`
+}
+
+type agentsList struct {
+	Embedded struct {
+		Agents []*Agent `json:"agents"`
+	} `json:"_embedded"`
+}
+
+type AgentsService struct {
+	c *Client
+}
+
+func (s *AgentsService) List(ctx context.Context) ([]*Agent, error) {
+	result := &agentsList{}
+	_, err := s.c.do(ctx, AgentsApi, http.MethodGet, agentsPath, nil, result)
+	return result.Embedded.Agents, err
+}
+
+func (s *AgentsService) Get(ctx context.Context, uuid string) (*Agent, error) {
+	result := &Agent{}
+	_, err := s.c.do(ctx, AgentsApi, http.MethodGet, apiPath(agentsPath, uuid), nil, result)
+	return result, err
+}
+
+func (s *AgentsService) Update(ctx context.Context, uuid string, update *AgentUpdate) (*Agent, error) {
+	result := &Agent{}
+	_, err := s.c.do(ctx, AgentsApi, http.MethodPatch, apiPath(agentsPath, uuid), update, result)
+	return result, err
+}
+
+func (s *AgentsService) BulkUpdate(ctx context.Context, update *AgentBulkUpdate) (string, error) {
+	result := &ApiMessage{}
+	_, err := s.c.do(ctx, AgentsApi, http.MethodPatch, agentsPath, update, result)
+	return result.String(), err
+}
+
+func (s *AgentsService) Delete(ctx context.Context, uuid string) (string, error) {
+	result := &ApiMessage{}
+	_, err := s.c.do(ctx, AgentsApi, http.MethodDelete, apiPath(agentsPath, uuid), nil, result)
+	return result.String(), err
+}
+
+// Deletes several agents at once; the server only allows deleting disabled
+// agents that are not building
+func (s *AgentsService) BulkDelete(ctx context.Context, uuids []string) (string, error) {
+	result := &ApiMessage{}
+	_, err := s.c.do(ctx, AgentsApi, http.MethodDelete, agentsPath, map[string][]string{`uuids`: uuids}, result)
+	return result.String(), err
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gocd-contrib/gocd-cli/dub"
+	"github.com/gocd-contrib/gocd-cli/utils"
+)
+
+// How long to wait between checks while the server zips a folder for
+// download
+var ZipPollInterval = 2 * time.Second
+
+// A file or folder in a job's artifacts
+type Artifact struct {
+	Name  string      `json:"name"`
+	Url   string      `json:"url"`
+	Type  string      `json:"type"`
+	Files []*Artifact `json:"files,omitempty"`
+}
+
+func (a *Artifact) IsFolder() bool {
+	return `folder` == a.Type
+}
+
+// Finds the artifact at the given slash-separated path beneath this one;
+// an empty path finds this artifact itself
+func (a *Artifact) Find(p string) *Artifact {
+	current := a
+
+	for _, name := range strings.Split(strings.Trim(path.Clean(`/`+p), `/`), `/`) {
+		if "" == name {
+			continue
+		}
+
+		var next *Artifact
+
+		for _, f := range current.Files {
+			if name == f.Name {
+				next = f
+				break
+			}
+		}
+
+		if nil == next {
+			return nil
+		}
+
+		current = next
+	}
+
+	return current
+}
+
+type ArtifactsService struct {
+	c *Client
+}
+
+func artifactPath(loc *JobLocator, p string) string {
+	segments := loc.segments()
+
+	for _, name := range strings.Split(strings.Trim(path.Clean(`/`+p), `/`), `/`) {
+		if "" != name {
+			segments = append(segments, name)
+		}
+	}
+
+	return apiPath(filesPath, segments...)
+}
+
+// Fetches the job's artifact tree, as a folder containing the top-level
+// artifacts
+func (s *ArtifactsService) Tree(ctx context.Context, loc *JobLocator) (*Artifact, error) {
+	root := &Artifact{Name: loc.Job, Type: `folder`}
+	_, err := s.c.V(Unversioned).Get(artifactPath(loc, ``)+`.json`).Decode(ctx, &root.Files)
+	return root, err
+}
+
+// Downloads an artifact file as destFolder/name, or a folder as a zip file;
+// see utils.SaveDownload. Returns the path of the downloaded file.
+func (s *ArtifactsService) Download(ctx context.Context, loc *JobLocator, artifact string, folder bool, destFolder, name string) (string, error) {
+	uri := artifactPath(loc, artifact)
+
+	if folder {
+		uri += `.zip`
+	}
+
+	var filepath string
+
+	err := utils.Poll(ctx, ZipPollInterval, func() (bool, error) {
+		// the server responds with 202 until it has zipped the folder
+		zipping := false
+
+		// a Req is configured as it is sent, so each attempt needs its own
+		err := s.c.V(Unversioned).Get(uri).Send(ctx, func(res *dub.Response) (err error) {
+			if http.StatusAccepted == res.Status {
+				zipping = true
+				return res.Consume(func(io.Reader) error { return nil })
+			}
+
+			filepath, err = utils.SaveDownload(res, name, destFolder)
+			return err
+		}, nil)
+
+		return !zipping, err
+	})
+
+	return filepath, err
+}
+
+// Uploads files into a folder of a job's artifacts; body is typically a
+// dub.Multipart with a `file` part
+func (s *ArtifactsService) Upload(ctx context.Context, loc *JobLocator, destFolder string, body io.Reader) (string, error) {
+	if "" == strings.Trim(destFolder, `/`) {
+		return "", errors.New(`The destination folder must not be empty`)
+	}
+
+	var msg string
+
+	err := s.c.V(Unversioned).Post(artifactPath(loc, destFolder), body, func(req *dub.Request) error {
+		req.Header(`Confirm`, `true`)
+		return nil
+	}).Send(ctx, func(res *dub.Response) error {
+		return ReadBodyAndDo(res, func(b []byte) error {
+			msg = strings.TrimSpace(string(b))
+			return nil
+		})
+	}, nil)
+
+	if err != nil {
+		return "", err
+	}
+
+	if "" == msg {
+		msg = fmt.Sprintf(`Uploaded to %s`, path.Join(loc.String(), destFolder))
+	}
+
+	return msg, nil
+}
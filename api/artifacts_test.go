@@ -0,0 +1,97 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/dub"
+)
+
+func TestArtifactsTreeFindsNestedArtifacts(t *testing.T) {
+	as := asserts(t)
+	rec := &recorder{}
+	c := testClient(t, rec.rt(200, `[{"name":"dist","type":"folder","files":[{"name":"app.jar","type":"file"},{"name":"lib","type":"folder","files":[]}]}]`))
+
+	loc, err := api.ParseJobLocator(`p/3/s/1/j`)
+	as.ok(err)
+
+	tree, err := c.Artifacts.Tree(context.Background(), loc)
+	as.ok(err)
+	as.eq(`/go/files/p/3/s/1/j.json`, rec.req.URL.Path)
+
+	as.is(tree == tree.Find(``))
+	as.is(tree.Find(`dist`).IsFolder())
+	as.eq(`app.jar`, tree.Find(`/dist/app.jar`).Name)
+	as.is(tree.Find(`dist/lib/`).IsFolder())
+	as.is(nil == tree.Find(`dist/nope`))
+	as.is(nil == tree.Find(`dist/app.jar/x`))
+}
+
+func TestArtifactsDownloadWaitsForZip(t *testing.T) {
+	as := asserts(t)
+	dir := t.TempDir()
+	requests := 0
+	var accepts [][]string
+
+	defer func(interval time.Duration) { api.ZipPollInterval = interval }(api.ZipPollInterval)
+	api.ZipPollInterval = time.Millisecond
+
+	c := testClient(t, mockRT(func(rq *http.Request) (*http.Response, error) {
+		requests++
+		accepts = append(accepts, rq.Header.Values(`Accept`))
+
+		rs := resp(200, `zipped`)
+		if requests < 3 {
+			rs = resp(202, `Artifacts are being zipped`)
+		}
+
+		rs.Request = rq
+		return rs, nil
+	}))
+
+	loc, err := api.ParseJobLocator(`p/3/s/1/j`)
+	as.ok(err)
+
+	file, err := c.Artifacts.Download(context.Background(), loc, `dist`, true, dir, `dist.zip`)
+	as.ok(err)
+	as.eq(3, requests)
+
+	// each poll sends a fresh request rather than piling up headers
+	for _, a := range accepts {
+		as.eq(`*/*`, strings.Join(a, `,`))
+	}
+
+	b, err := os.ReadFile(file)
+	as.ok(err)
+	as.eq(`zipped`, string(b))
+}
+
+func TestArtifactsUploadPostsMultipart(t *testing.T) {
+	as := asserts(t)
+	rec := &recorder{}
+	c := testClient(t, rec.rt(201, `File reports/junit.xml was created successfully`))
+
+	loc, err := api.ParseJobLocator(`p/3/s/1/j`)
+	as.ok(err)
+
+	body := dub.NewPipedMultipart()
+	body.AddFileStream(`file`, `junit.xml`, strings.NewReader(`<testsuite/>`))
+
+	msg, err := c.Artifacts.Upload(context.Background(), loc, `reports`, body)
+	as.ok(err)
+	as.eq(`File reports/junit.xml was created successfully`, msg)
+
+	as.eq(`/go/files/p/3/s/1/j/reports`, rec.req.URL.Path)
+	as.eq(`true`, rec.req.Header.Get(`Confirm`))
+	as.is(strings.HasPrefix(rec.req.Header.Get(`Content-Type`), `multipart/form-data`))
+	as.is(strings.Contains(rec.body, `filename="junit.xml"`))
+	as.is(strings.Contains(rec.body, `<testsuite/>`))
+
+	_, err = c.Artifacts.Upload(context.Background(), loc, `/`, body)
+	as.err(`The destination folder must not be empty`, err)
+}
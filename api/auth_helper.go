@@ -0,0 +1,56 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/gocd-contrib/gocd-cli/utils"
+)
+
+const AUTH_HELPER_URL_ENV = `GOCD_SERVER_URL`
+
+// helpers may be slow (e.g., prompting to unlock a keyring), so only
+// run each one once per invocation
+var helperTokens = struct {
+	sync.Mutex
+	cache map[string]string
+}{cache: make(map[string]string)}
+
+// Runs an external credential helper and returns the token from the first
+// line of its STDOUT. The server URL is passed to the helper through the
+// GOCD_SERVER_URL environment variable so that a single helper can serve
+// several profiles. STDERR is passed through so helpers may prompt the user.
+func RunAuthHelper(command, serverUrl string) (string, error) {
+	helperTokens.Lock()
+	defer helperTokens.Unlock()
+
+	cacheKey := command + "\n" + serverUrl
+
+	if token, ok := helperTokens.cache[cacheKey]; ok {
+		return token, nil
+	}
+
+	utils.Debug(`Running auth helper %q`, command)
+
+	cmd := utils.ShellCommand(command)
+	cmd.Env = append(os.Environ(), AUTH_HELPER_URL_ENV+`=`+serverUrl)
+	cmd.Stdin = os.Stdin
+	cmd.Stderr = utils.StderrOrDevNull()
+
+	out, err := cmd.Output()
+
+	if err != nil {
+		return "", fmt.Errorf(`Auth helper %q failed: %v`, command, err)
+	}
+
+	token := strings.TrimSpace(strings.SplitN(string(out), "\n", 2)[0])
+
+	if "" == token {
+		return "", fmt.Errorf(`Auth helper %q did not print a token`, command)
+	}
+
+	helperTokens.cache[cacheKey] = token
+	return token, nil
+}
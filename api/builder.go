@@ -9,8 +9,13 @@ import (
 
 	"github.com/gocd-contrib/gocd-cli/cfg"
 	"github.com/gocd-contrib/gocd-cli/dub"
+	"github.com/gocd-contrib/gocd-cli/utils"
 )
 
+// Builders pinned to an API version.
+//
+// Deprecated: prefer the Client services, which negotiate the API version
+// with the server.
 var (
 	V1 = V(1)
 	V2 = V(2)
@@ -21,31 +26,36 @@ var (
 	V7 = V(7)
 )
 
+// The API version of a Builder for resources served as-is rather than
+// through a versioned API, e.g., artifacts
+const Unversioned = 0
+
 type Builder struct {
 	ApiVersion int
 
 	conf *cfg.Config
 	c    *dub.Client
+	err  error // failure to build the client; reported by Validate()
 }
 
 func (b *Builder) Get(path string, onCreate ...CreateHook) *Req {
-	return NewReq(b.c.Get(b.Url(path)), nil, b, onCreate)
+	return NewReq(b.client().Get(b.Url(path)), nil, b, onCreate)
 }
 
 func (b *Builder) Put(path string, data io.Reader, onCreate ...CreateHook) *Req {
-	return NewReq(b.c.Put(b.Url(path)), data, b, b.AddConfirmHeaderIfBodyNil(onCreate))
+	return NewReq(b.client().Put(b.Url(path)), data, b, b.AddConfirmHeaderIfBodyNil(onCreate))
 }
 
 func (b *Builder) Patch(path string, data io.Reader, onCreate ...CreateHook) *Req {
-	return NewReq(b.c.Patch(b.Url(path)), data, b, b.AddConfirmHeaderIfBodyNil(onCreate))
+	return NewReq(b.client().Patch(b.Url(path)), data, b, b.AddConfirmHeaderIfBodyNil(onCreate))
 }
 
 func (b *Builder) Post(path string, data io.Reader, onCreate ...CreateHook) *Req {
-	return NewReq(b.c.Post(b.Url(path)), data, b, b.AddConfirmHeaderIfBodyNil(onCreate))
+	return NewReq(b.client().Post(b.Url(path)), data, b, b.AddConfirmHeaderIfBodyNil(onCreate))
 }
 
 func (b *Builder) Delete(path string, data io.Reader, onCreate ...CreateHook) *Req {
-	return NewReq(b.c.Delete(b.Url(path)), data, b, onCreate)
+	return NewReq(b.client().Delete(b.Url(path)), data, b, onCreate)
 }
 
 func (b *Builder) AddConfirmHeaderIfBodyNil(onCreate []CreateHook) []CreateHook {
@@ -58,7 +68,17 @@ func (b *Builder) AddConfirmHeaderIfBodyNil(onCreate []CreateHook) []CreateHook
 }
 
 func (b *Builder) Validate() error {
-	return b.conf.WithBaseUrlValidation(b.conf.GetServerUrl(), nil)
+	if b.err != nil {
+		return utils.InspectError(b.err, `building http client`)
+	}
+
+	if err := b.conf.WithBaseUrlValidation(b.conf.GetServerUrl(), nil); err != nil {
+		if name := b.conf.ActiveProfile(); cfg.DEFAULT_PROFILE != name && !b.conf.HasProfile(name) {
+			return fmt.Errorf("%v; profile %q does not exist (see `gocd config profile add`)", err, name)
+		}
+		return err
+	}
+	return nil
 }
 
 func (b *Builder) Url(uri string) string {
@@ -66,6 +86,9 @@ func (b *Builder) Url(uri string) string {
 }
 
 func (b *Builder) AcceptHeader() string {
+	if Unversioned == b.ApiVersion {
+		return `*/*`
+	}
 	return `application/vnd.go.cd.v` + strconv.Itoa(b.ApiVersion) + `+json`
 }
 
@@ -97,6 +120,16 @@ func (b *Builder) Auth() (dub.AuthSpec, error) {
 		}
 
 		return dub.NewTokenAuth(auth[`token`]), nil
+	case `helper`:
+		if err := checkAuth(auth, `command`); err != nil {
+			return nil, err
+		}
+
+		if token, err := RunAuthHelper(auth[`command`], b.conf.GetServerUrl()); err != nil {
+			return nil, err
+		} else {
+			return dub.NewTokenAuth(token), nil
+		}
 	case `none`:
 		return nil, nil
 	default:
@@ -111,10 +144,23 @@ func checkAuth(auth map[string]string, key string) error {
 	return nil
 }
 
+// Builds the client lazily as these builders are created before the config
+// is loaded
+func (b *Builder) client() *dub.Client {
+	if nil == b.c {
+		if b.c, b.err = NewHttpClient(b.conf); b.err != nil {
+			b.c = dub.New()
+		}
+	}
+	return b.c
+}
+
 func V(version int) *Builder {
-	return New(version, cfg.Conf(), dub.New())
+	return New(version, cfg.Conf(), nil)
 }
 
+// Creates a Builder; if client is nil, one will be created from the config
+// upon first use
 func New(version int, config *cfg.Config, client *dub.Client) *Builder {
 	return &Builder{ApiVersion: version, conf: config, c: client}
 }
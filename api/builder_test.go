@@ -150,3 +150,62 @@ func TestIntegrationUpdateMethodsHaveConfirmHeaderUponConfig(t *testing.T) {
 		as.not(isSet)
 	}
 }
+
+func TestAuthRunsHelperCommand(t *testing.T) {
+	as := asserts(t)
+
+	c, err := makeConf(`
+auth:
+  type: helper
+  command: echo from-helper; echo ignored
+`)
+	as.ok(err)
+	v := testApi(999, c)
+
+	auth, err := v.Auth()
+	as.ok(err)
+	as.eq(`Bearer from-helper`, auth.Token())
+}
+
+func TestAuthFailsWhenHelperFails(t *testing.T) {
+	as := asserts(t)
+
+	c, err := makeConf(`
+auth:
+  type: helper
+  command: exit 3
+`)
+	as.ok(err)
+	v := testApi(999, c)
+
+	_, err = v.Auth()
+	as.err(`Auth helper "exit 3" failed: exit status 3`, err)
+}
+
+func TestAuthFailsWhenHelperPrintsNothing(t *testing.T) {
+	as := asserts(t)
+
+	c, err := makeConf(`
+auth:
+  type: helper
+  command: echo
+`)
+	as.ok(err)
+	v := testApi(999, c)
+
+	_, err = v.Auth()
+	as.err(`Auth helper "echo" did not print a token`, err)
+}
+
+func TestAuthFailsWhenMissingHelperCommand(t *testing.T) {
+	as := asserts(t)
+
+	c, err := makeConf(`
+auth:
+  type: helper
+`)
+	as.ok(err)
+	v := testApi(999, c)
+	_, err = v.Auth()
+	as.err(`Failed to construct authentication spec; "command" is missing`, err)
+}
@@ -0,0 +1,205 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/gocd-contrib/gocd-cli/cfg"
+	"github.com/gocd-contrib/gocd-cli/dub"
+	"github.com/gocd-contrib/gocd-cli/utils"
+)
+
+// A client for the GoCD server configured in the active profile, built
+// lazily from the config upon first use
+var DefaultClient = NewClient(cfg.Conf(), nil)
+
+// A typed client for the GoCD REST API, organized into resource services
+type Client struct {
+	ConfigRepos    *ConfigReposService
+	Pipelines      *PipelinesService
+	Stages         *StagesService
+	Jobs           *JobsService
+	Agents         *AgentsService
+	Environments   *EnvironmentsService
+	PipelineGroups *PipelineGroupsService
+	Artifacts      *ArtifactsService
+	Server         *ServerService
+
+	base *Builder
+	memo versionMemo
+}
+
+// Creates a Client; if httpClient is nil, one will be created from the
+// config upon first use
+func NewClient(conf *cfg.Config, httpClient *dub.Client) *Client {
+	c := &Client{base: New(0, conf, httpClient)}
+
+	c.ConfigRepos = &ConfigReposService{c}
+	c.Pipelines = &PipelinesService{c}
+	c.Stages = &StagesService{c}
+	c.Jobs = &JobsService{c}
+	c.Agents = &AgentsService{c}
+	c.Environments = &EnvironmentsService{c}
+	c.PipelineGroups = &PipelineGroupsService{c}
+	c.Artifacts = &ArtifactsService{c}
+	c.Server = &ServerService{c}
+
+	return c
+}
+
+// Returns a Builder for the given API version that shares this client's
+// connection settings
+func (c *Client) V(version int) *Builder {
+	httpClient := c.base.client()
+	return &Builder{ApiVersion: version, conf: c.base.conf, c: httpClient, err: c.base.err}
+}
+
+// Sends a request to a JSON API endpoint, negotiating its version with the
+// server. The body (if non-nil) is encoded as JSON, and a successful
+// response is decoded into result (if non-nil). Returns the response
+// headers, e.g., to read an ETag.
+func (c *Client) do(ctx context.Context, ep *Endpoint, method, uri string, body, result interface{}, onCreate ...CreateHook) (http.Header, error) {
+	var payload []byte
+
+	if nil != body {
+		if b, err := json.Marshal(body); err != nil {
+			return nil, utils.InspectError(err, `encoding %s %s request body`, method, uri)
+		} else {
+			payload = b
+			onCreate = append(onCreate, ContentType(`application/json`))
+		}
+	}
+
+	b, err := c.For(ctx, ep)
+
+	if err != nil {
+		return nil, err
+	}
+
+	headers, err := c.send(ctx, b, method, uri, payload, result, onCreate)
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || http.StatusNotAcceptable != apiErr.Status {
+		return headers, err
+	}
+
+	// the server no longer serves the version picked for it, so it has likely
+	// been upgraded or downgraded since its version was cached; detect it
+	// again, and retry once if that changes the version to use
+	c.forgetServerVersion()
+
+	if retry, rerr := c.For(ctx, ep); nil == rerr && retry.ApiVersion != b.ApiVersion {
+		utils.Debug(`retrying %s %s with v%d of the %s API`, method, uri, retry.ApiVersion, ep.Name)
+		b = retry
+		headers, err = c.send(ctx, b, method, uri, payload, result, onCreate)
+	} else if nil != rerr {
+		return headers, rerr
+	}
+
+	if errors.As(err, &apiErr) && http.StatusNotAcceptable == apiErr.Status {
+		apiErr.Message = fmt.Sprintf(`GoCD server does not serve v%d of the %s API`, b.ApiVersion, ep.Name)
+	}
+
+	return headers, err
+}
+
+func (c *Client) send(ctx context.Context, b *Builder, method, uri string, payload []byte, result interface{}, onCreate []CreateHook) (http.Header, error) {
+	var data io.Reader
+
+	if nil != payload {
+		data = bytes.NewReader(payload)
+	}
+
+	var req *Req
+
+	switch method {
+	case http.MethodGet:
+		req = b.Get(uri, onCreate...)
+	case http.MethodPost:
+		req = b.Post(uri, data, onCreate...)
+	case http.MethodPut:
+		req = b.Put(uri, data, onCreate...)
+	case http.MethodPatch:
+		req = b.Patch(uri, data, onCreate...)
+	case http.MethodDelete:
+		req = b.Delete(uri, data, onCreate...)
+	default:
+		panic(`Unsupported method: ` + method)
+	}
+
+	return req.Decode(ctx, result)
+}
+
+// Sends the request and decodes a successful JSON response into result,
+// unless result is nil; error responses are returned as an *Error. Returns
+// the response headers.
+func (r *Req) Decode(ctx context.Context, result interface{}) (http.Header, error) {
+	var headers http.Header
+
+	err := r.Send(ctx, func(res *dub.Response) error {
+		headers = res.Headers
+
+		return ReadBodyAndDo(res, func(b []byte) error {
+			if nil == result || 0 == len(bytes.TrimSpace(b)) {
+				return nil
+			}
+
+			return utils.InspectError(json.Unmarshal(b, result), `parsing %s %s response`, r.Raw.Method, r.Raw.Url)
+		})
+	}, nil)
+
+	return headers, err
+}
+
+// Sets the Content-Type header on the request
+func ContentType(contentType string) CreateHook {
+	return func(req *dub.Request) error {
+		req.ContentType(contentType)
+		return nil
+	}
+}
+
+// Makes a request conditional upon the resource's ETag, so that concurrent
+// modifications are rejected with a 412
+func IfMatch(etag string) CreateHook {
+	return func(req *dub.Request) error {
+		if "" != etag {
+			req.Header(`If-Match`, etag)
+		}
+		return nil
+	}
+}
+
+// Joins escaped path segments onto an API path
+func apiPath(base string, segments ...string) string {
+	escaped := make([]string, 0, len(segments)+1)
+	escaped = append(escaped, base)
+
+	for _, s := range segments {
+		escaped = append(escaped, url.PathEscape(s))
+	}
+
+	return path.Join(escaped...)
+}
+
+// A minimal representation of a named entity in a list, e.g., the pipelines
+// in an environment
+type NameRef struct {
+	Name string `json:"name"`
+}
+
+// Returns the names of the referenced entities
+func Names(refs []NameRef) []string {
+	names := make([]string, 0, len(refs))
+	for _, r := range refs {
+		names = append(names, r.Name)
+	}
+	return names
+}
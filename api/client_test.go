@@ -0,0 +1,527 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/dub"
+)
+
+// Records the last request sent, responding with the given status and body
+type recorder struct {
+	req  *http.Request
+	body string
+}
+
+func (r *recorder) rt(status int, body string, headers ...string) mockRT {
+	return mockRT(func(rq *http.Request) (*http.Response, error) {
+		r.req = rq
+
+		if nil != rq.Body {
+			b, _ := ioutil.ReadAll(rq.Body)
+			r.body = string(b)
+		}
+
+		rs := resp(status, body)
+		rs.Request = rq
+
+		for i := 0; i+1 < len(headers); i += 2 {
+			rs.Header.Set(headers[i], headers[i+1])
+		}
+		return rs, nil
+	})
+}
+
+func testClient(t *testing.T, rt mockRT) *api.Client {
+	t.Helper()
+
+	c, err := makeConf(`
+server:
+  url: http://test/go
+auth:
+  type: none
+`)
+
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	// skips version detection
+	if err = c.CacheServerVersion(`http://test/go`, `24.1.0`); nil != err {
+		t.Fatal(err)
+	}
+
+	return api.NewClient(c, dub.Make(rt))
+}
+
+func TestConfigReposListDecodesEmbedded(t *testing.T) {
+	as := asserts(t)
+	rec := &recorder{}
+	c := testClient(t, rec.rt(200, `{"_embedded":{"config_repos":[{"id":"a","plugin_id":"yaml.config.plugin","material":{"type":"git","attributes":{"url":"https://example.com/a.git"}}}]}}`))
+
+	repos, err := c.ConfigRepos.List(context.Background())
+	as.ok(err)
+
+	as.eq(`GET`, rec.req.Method)
+	as.eq(`http://test/go/api/admin/config_repos`, rec.req.URL.String())
+	as.eq(`application/vnd.go.cd.v4+json`, rec.req.Header.Get(`Accept`))
+
+	as.eq(1, len(repos))
+	as.eq(`a`, repos[0].Id)
+	as.eq(`git`, repos[0].Material.Type)
+	as.eq(`https://example.com/a.git`, repos[0].Material.Attributes[`url`])
+}
+
+func TestConfigReposGetAndUpdateUseETag(t *testing.T) {
+	as := asserts(t)
+	rec := &recorder{}
+	c := testClient(t, rec.rt(200, `{"id":"my repo","plugin_id":"json.config.plugin"}`, `ETag`, `"abc"`))
+
+	repo, etag, err := c.ConfigRepos.Get(context.Background(), `my repo`)
+	as.ok(err)
+	as.eq(`/go/api/admin/config_repos/my%20repo`, rec.req.URL.EscapedPath())
+	as.eq(`"abc"`, etag)
+	as.eq(`json.config.plugin`, repo.PluginId)
+
+	_, _, err = c.ConfigRepos.Update(context.Background(), repo, etag)
+	as.ok(err)
+	as.eq(`PUT`, rec.req.Method)
+	as.eq(`"abc"`, rec.req.Header.Get(`If-Match`))
+	as.eq(`application/json`, rec.req.Header.Get(`Content-Type`))
+	as.eq(`{"id":"my repo","plugin_id":"json.config.plugin","material":{"type":"","attributes":null}}`, rec.body)
+}
+
+func TestConfigReposDeleteReturnsMessage(t *testing.T) {
+	as := asserts(t)
+	rec := &recorder{}
+	c := testClient(t, rec.rt(200, `{"message":"The config repo 'a' was deleted successfully."}`))
+
+	msg, err := c.ConfigRepos.Delete(context.Background(), `a`)
+	as.ok(err)
+	as.eq(`DELETE`, rec.req.Method)
+	as.eq(`The config repo 'a' was deleted successfully.`, msg)
+}
+
+func TestServicesReturnApiErrors(t *testing.T) {
+	as := asserts(t)
+	rec := &recorder{}
+	c := testClient(t, rec.rt(404, `{"message":"Either the resource you requested was not found, or you are not authorized to perform this action."}`))
+
+	_, err := c.Pipelines.Status(context.Background(), `nope`)
+	as.err(`No such pipeline: "nope"`, api.NotFound(err, `No such pipeline: %q`, `nope`))
+
+	apiErr, ok := err.(*api.Error)
+	as.is(ok)
+	as.is(apiErr.IsNotFound())
+}
+
+func TestPipelinesScheduleSendsOptions(t *testing.T) {
+	as := asserts(t)
+	rec := &recorder{}
+	c := testClient(t, rec.rt(202, `{"message":"Request to schedule pipeline p accepted"}`))
+
+	msg, err := c.Pipelines.Schedule(context.Background(), `p`, &api.ScheduleOptions{
+		EnvironmentVariables: []api.EnvironmentVariable{{Name: `FOO`, Value: `bar`}},
+	})
+	as.ok(err)
+
+	as.eq(`POST`, rec.req.Method)
+	as.eq(`/go/api/pipelines/p/schedule`, rec.req.URL.Path)
+	as.eq(`application/vnd.go.cd.v1+json`, rec.req.Header.Get(`Accept`))
+	as.eq(`{"environment_variables":[{"name":"FOO","value":"bar","secure":false}]}`, rec.body)
+	as.eq(`Request to schedule pipeline p accepted`, msg)
+}
+
+func TestPipelinesUnpauseSendsConfirmHeader(t *testing.T) {
+	as := asserts(t)
+	rec := &recorder{}
+	c := testClient(t, rec.rt(200, `{"message":"ok"}`))
+
+	_, err := c.Pipelines.Unpause(context.Background(), `p`)
+	as.ok(err)
+	as.eq(`true`, rec.req.Header.Get(`X-GoCD-Confirm`))
+}
+
+func TestPipelineHistoryDecodesStringCounters(t *testing.T) {
+	as := asserts(t)
+	rec := &recorder{}
+	c := testClient(t, rec.rt(200, `{"_links":{"next":{"href":"http://test/go/api/pipelines/p/history?after=3"}},"pipelines":[{"name":"p","counter":4,"stages":[{"name":"build","counter":"2","result":"Passed","jobs":[{"name":"compile","state":"Completed","result":"Passed"}]}]}]}`))
+
+	history, err := c.Pipelines.History(context.Background(), `p`)
+	as.ok(err)
+
+	as.eq(`http://test/go/api/pipelines/p/history?after=3`, history.Links.Next.Href)
+	as.eq(4, history.Pipelines[0].Counter)
+
+	stage := history.Pipelines[0].Stage(`build`)
+	as.eq(api.Counter(2), stage.Counter)
+	as.is(stage.IsCompleted())
+	as.is(stage.Jobs[0].IsCompleted())
+	as.is(nil == history.Pipelines[0].Stage(`deploy`))
+}
+
+func TestStagesUseLocatorPaths(t *testing.T) {
+	as := asserts(t)
+	rec := &recorder{}
+	c := testClient(t, rec.rt(202, `{"message":"ok"}`))
+	loc := &api.StageLocator{Pipeline: `p`, PipelineCounter: 3, Stage: `s`, StageCounter: 1}
+
+	_, err := c.Stages.RunSelectedJobs(context.Background(), loc, []string{`a`, `b`})
+	as.ok(err)
+	as.eq(`/go/api/stages/p/3/s/1/run-selected-jobs`, rec.req.URL.Path)
+	as.eq(`application/vnd.go.cd.v3+json`, rec.req.Header.Get(`Accept`))
+	as.eq(`{"jobs":["a","b"]}`, rec.body)
+
+	_, err = c.Stages.Run(context.Background(), `p`, 3, `s`)
+	as.ok(err)
+	as.eq(`/go/api/stages/p/3/s/run`, rec.req.URL.Path)
+	as.eq(`application/vnd.go.cd.v2+json`, rec.req.Header.Get(`Accept`))
+}
+
+func TestJobsInstanceUsesLocatorPath(t *testing.T) {
+	as := asserts(t)
+	rec := &recorder{}
+	c := testClient(t, rec.rt(200, `{"name":"j","state":"Building","pipeline_counter":3,"stage_counter":"1"}`))
+
+	job, err := c.Jobs.Instance(context.Background(), &api.JobLocator{
+		StageLocator: api.StageLocator{Pipeline: `p`, PipelineCounter: 3, Stage: `s`, StageCounter: 1},
+		Job:          `j`,
+	})
+	as.ok(err)
+	as.eq(`/go/api/jobs/p/3/s/1/j`, rec.req.URL.Path)
+	as.eq(api.Counter(1), job.StageCounter)
+	as.not(job.IsCompleted())
+}
+
+func TestAgentsBulkUpdate(t *testing.T) {
+	as := asserts(t)
+	rec := &recorder{}
+	c := testClient(t, rec.rt(200, `{"message":"Updated agent(s) with uuid(s): [a, b]."}`))
+
+	_, err := c.Agents.BulkUpdate(context.Background(), &api.AgentBulkUpdate{
+		Uuids:      []string{`a`, `b`},
+		Operations: &api.AgentBulkOperation{Resources: &api.AddRemove{Add: []string{`linux`}}},
+	})
+	as.ok(err)
+
+	as.eq(`PATCH`, rec.req.Method)
+	as.eq(`/go/api/agents`, rec.req.URL.Path)
+	as.eq(`application/vnd.go.cd.v7+json`, rec.req.Header.Get(`Accept`))
+	as.eq(`{"uuids":["a","b"],"operations":{"resources":{"add":["linux"]}}}`, rec.body)
+}
+
+func TestEnvironmentEditsPipelinesAndVariables(t *testing.T) {
+	as := asserts(t)
+	env := &api.Environment{Name: `prod`, Pipelines: []api.NameRef{{`a`}}}
+
+	env.AddPipeline(`b`)
+	env.AddPipeline(`a`)
+	as.eq(`a,b`, strings.Join(api.Names(env.Pipelines), `,`))
+	as.is(env.RemovePipeline(`a`))
+	as.not(env.RemovePipeline(`a`))
+	as.eq(`b`, strings.Join(api.Names(env.Pipelines), `,`))
+
+	v, err := api.ParseEnvironmentVariable(`TOKEN=a=b`, true)
+	as.ok(err)
+	env.SetVariable(v)
+	env.SetVariable(api.EnvironmentVariable{Name: `TOKEN`, Value: `c`})
+	as.eq(1, len(env.EnvironmentVariables))
+	as.eq(`c`, env.EnvironmentVariables[0].Value)
+	as.is(env.RemoveVariable(`TOKEN`))
+	as.eq(0, len(env.EnvironmentVariables))
+
+	_, err = api.ParseEnvironmentVariable(`=x`, false)
+	as.err(`Invalid environment variable "=x"; expected NAME=VALUE`, err)
+}
+
+func TestPipelineGroupsList(t *testing.T) {
+	as := asserts(t)
+	rec := &recorder{}
+	c := testClient(t, rec.rt(200, `{"_embedded":{"groups":[{"name":"first","authorization":{"view":{"users":["alice"],"roles":[]}},"pipelines":[{"name":"up42"}]}]}}`))
+
+	groups, err := c.PipelineGroups.List(context.Background())
+	as.ok(err)
+
+	as.eq(`/go/api/admin/pipeline_groups`, rec.req.URL.Path)
+	as.eq(`application/vnd.go.cd.v1+json`, rec.req.Header.Get(`Accept`))
+	as.eq(`first`, groups[0].Name)
+	as.eq(`alice`, groups[0].Authorization.View.Users[0])
+	as.eq(`up42`, groups[0].Pipelines[0].Name)
+}
+
+func TestPipelineGroupGrantAndRevoke(t *testing.T) {
+	as := asserts(t)
+	group := &api.PipelineGroup{Name: `first`}
+
+	users, roles := group.Grantees(api.PermissionView)
+	as.eq(0, len(users)+len(roles))
+
+	as.ok(group.Revoke(api.PermissionAdmin, []string{`alice`}, nil))
+	as.is(nil == group.Authorization)
+
+	as.ok(group.Grant(api.PermissionOperate, []string{`alice`, `bob`}, []string{`dev`}))
+	as.ok(group.Grant(api.PermissionOperate, []string{`ALICE`}, nil))
+	users, roles = group.Grantees(api.PermissionOperate)
+	as.eq(`alice,bob`, strings.Join(users, `,`))
+	as.eq(`dev`, strings.Join(roles, `,`))
+	as.is(nil == group.Authorization.View)
+
+	as.ok(group.Revoke(api.PermissionOperate, []string{`Bob`}, []string{`dev`}))
+	users, roles = group.Grantees(api.PermissionOperate)
+	as.eq(`alice`, strings.Join(users, `,`))
+	as.eq(0, len(roles))
+
+	b, err := json.Marshal(group.Authorization)
+	as.ok(err)
+	as.eq(`{"operate":{"users":["alice"],"roles":[]}}`, string(b))
+
+	as.err(`Unknown permission "own"; expected view, operate, or admin`, group.Grant(`own`, []string{`alice`}, nil))
+}
+
+func TestPipelineGroupIsRestrictedUntilLastGranteeIsRevoked(t *testing.T) {
+	as := asserts(t)
+	group := &api.PipelineGroup{Name: `first`}
+	as.not(group.IsRestricted())
+
+	as.ok(group.Grant(api.PermissionAdmin, []string{`lastadmin`}, nil))
+	as.ok(group.Grant(api.PermissionView, nil, []string{`dev`}))
+	as.is(group.IsRestricted())
+
+	as.ok(group.Revoke(api.PermissionView, nil, []string{`dev`}))
+	as.is(group.IsRestricted())
+
+	as.ok(group.Revoke(api.PermissionAdmin, []string{`LastAdmin`}, nil))
+	as.not(group.IsRestricted())
+}
+
+func TestParseInfoErrorsGroupsMessagesByLocation(t *testing.T) {
+	as := asserts(t)
+
+	info := &api.ParseInfo{Error: "pipelines/build.gocd.yaml;\n- Invalid stage;\n- Missing jobs;\nenvs.gocd.yaml;\n- Unknown environment;\n"}
+	as.eq("pipelines/build.gocd.yaml:\n  - Invalid stage\n\npipelines/build.gocd.yaml:\n  - Missing jobs\n\nenvs.gocd.yaml:\n  - Unknown environment", info.Errors(`my-repo`).DisplayErrors())
+
+	info = &api.ParseInfo{Error: `Failed to fetch material: repository not found`}
+	as.eq("my-repo:\n  - Failed to fetch material: repository not found", info.Errors(`my-repo`).DisplayErrors())
+
+	as.eq(0, len((*api.ParseInfo)(nil).Errors(`my-repo`).Errors))
+}
+
+func TestConfigRepoParseStatus(t *testing.T) {
+	as := asserts(t)
+
+	repo := &api.ConfigRepo{}
+	as.eq(`not parsed`, repo.ParseStatus())
+
+	repo.ParseInfo = &api.ParseInfo{LatestParsedModification: &api.Modification{Revision: `abc`}}
+	as.eq(`ok`, repo.ParseStatus())
+
+	repo.ParseInfo.Error = `bad`
+	as.eq(`error`, repo.ParseStatus())
+
+	repo.MaterialUpdateInProgress = true
+	as.eq(`updating`, repo.ParseStatus())
+}
+
+func TestPipelineInstanceOutcome(t *testing.T) {
+	as := asserts(t)
+	run := func(stages ...*api.StageResult) *api.PipelineInstance {
+		return &api.PipelineInstance{Stages: stages}
+	}
+	stage := func(scheduled bool, approval, result string) *api.StageResult {
+		return &api.StageResult{Scheduled: scheduled, ApprovalType: approval, Result: result}
+	}
+
+	done, result := run(stage(true, `success`, `Passed`), stage(true, `success`, `Building`)).Outcome()
+	as.not(done)
+
+	done, result = run(stage(true, `success`, `Passed`), stage(false, `success`, `Unknown`)).Outcome()
+	as.not(done)
+
+	done, result = run(stage(true, `success`, `Passed`), stage(false, `manual`, `Unknown`)).Outcome()
+	as.is(done)
+	as.eq(`Passed`, result)
+
+	done, result = run(stage(true, `success`, `Failed`), stage(false, `success`, `Unknown`)).Outcome()
+	as.is(done)
+	as.eq(`Failed`, result)
+
+	done, result = run(stage(true, `success`, `Passed`), stage(true, `success`, `Passed`)).Outcome()
+	as.is(done)
+	as.eq(`Passed`, result)
+
+	done, _ = (&api.PipelineInstance{PreparingToSchedule: true}).Outcome()
+	as.not(done)
+}
+
+func TestJobsConsoleLogResumesWithRangeRequests(t *testing.T) {
+	as := asserts(t)
+	const log = "line 1\nline 2\nline 3\n"
+	var ranges []string
+
+	c := testClient(t, mockRT(func(rq *http.Request) (*http.Response, error) {
+		ranges = append(ranges, rq.Header.Get(`Range`))
+
+		var rs *http.Response
+
+		switch rq.Header.Get(`Range`) {
+		case ``:
+			rs = resp(200, log[:7])
+		case `bytes=7-`:
+			rs = resp(206, log[7:])
+		case `bytes=21-`:
+			rs = resp(416, ``)
+		default:
+			rs = resp(200, log) // ignores the range
+		}
+
+		rs.Request = rq
+		return rs, nil
+	}))
+
+	loc, err := api.ParseJobLocator(`p/3/s/1/j`)
+	as.ok(err)
+
+	out := &strings.Builder{}
+	offset, err := c.Jobs.ConsoleLog(context.Background(), loc, 0, out)
+	as.ok(err)
+	as.eq(int64(7), offset)
+
+	offset, err = c.Jobs.ConsoleLog(context.Background(), loc, offset, out)
+	as.ok(err)
+	as.eq(int64(21), offset)
+	as.eq(log, out.String())
+
+	offset, err = c.Jobs.ConsoleLog(context.Background(), loc, offset, out)
+	as.ok(err)
+	as.eq(int64(21), offset)
+
+	out.Reset()
+	offset, err = c.Jobs.ConsoleLog(context.Background(), loc, 14, out)
+	as.ok(err)
+	as.eq(int64(21), offset)
+	as.eq("line 3\n", out.String())
+
+	as.eq(``, ranges[0])
+	as.eq(`bytes=14-`, ranges[3])
+}
+
+func TestJobsConsoleLogIsUnversioned(t *testing.T) {
+	as := asserts(t)
+	rec := &recorder{}
+	c := testClient(t, rec.rt(404, `{"message":"not found"}`))
+
+	loc, err := api.ParseJobLocator(`p/3/s/1/j`)
+	as.ok(err)
+
+	_, err = c.Jobs.ConsoleLog(context.Background(), loc, 0, io.Discard)
+	as.is(err.(*api.Error).IsNotFound())
+	as.eq(`/go/files/p/3/s/1/j/cruise-output/console.log`, rec.req.URL.Path)
+	as.eq(`*/*`, rec.req.Header.Get(`Accept`))
+}
+
+func TestMatchAgentsByUuidHostnameOrGlob(t *testing.T) {
+	as := asserts(t)
+	agents := []*api.Agent{
+		{Uuid: `u1`, Hostname: `build-1`},
+		{Uuid: `u2`, Hostname: `build-2`},
+		{Uuid: `u3`, Hostname: `deploy-1`},
+	}
+
+	matched, err := api.MatchAgents(agents, []string{`deploy-1`, `build-*`, `u1`})
+	as.ok(err)
+	as.eq(3, len(matched))
+	as.eq(`u3`, matched[0].Uuid)
+	as.eq(`u1`, matched[1].Uuid)
+	as.eq(`u2`, matched[2].Uuid)
+	as.eq(`u3,u1,u2`, strings.Join(api.AgentUuids(matched), `,`))
+
+	_, err = api.MatchAgents(agents, []string{`build-*`, `test-*`})
+	as.err(`No agent matches "test-*"`, err)
+
+	_, err = api.MatchAgents(agents, []string{`build-[`})
+	as.err(`Invalid hostname pattern "build-[": syntax error in pattern`, err)
+}
+
+func TestServerHealthIsUnversioned(t *testing.T) {
+	as := asserts(t)
+	rec := &recorder{}
+	c := testClient(t, rec.rt(200, `{"health":"OK"}`))
+
+	h, err := c.Server.Health(context.Background())
+	as.ok(err)
+	as.eq(`OK`, h.Health)
+	as.eq(`/go/api/v1/health`, rec.req.URL.Path)
+	as.eq(`*/*`, rec.req.Header.Get(`Accept`))
+}
+
+func TestServerMaintenanceModeListsRunningSystems(t *testing.T) {
+	as := asserts(t)
+	rec := &recorder{}
+	c := testClient(t, rec.rt(200, `{
+  "is_maintenance_mode": true,
+  "metadata": {"updated_by": "admin", "updated_on": "2026-10-18T10:00:00Z"},
+  "attributes": {
+    "has_running_systems": true,
+    "running_systems": {
+      "material_update_in_progress": [{"type": "git", "attributes": {"url": "https://x/app.git"}, "mdu_start_time": "2026-10-18T10:01:00Z"}],
+      "building_jobs": [{"pipeline_name": "p", "pipeline_counter": 3, "stage_name": "s", "stage_counter": "1", "name": "j", "state": "Building"}],
+      "scheduled_jobs": []
+    }
+  }
+}`))
+
+	info, err := c.Server.MaintenanceMode(context.Background())
+	as.ok(err)
+	as.eq(`/go/api/admin/maintenance_mode/info`, rec.req.URL.Path)
+	as.is(info.Enabled)
+	as.eq(`admin`, info.Metadata.UpdatedBy)
+	as.is(info.Attributes.HasRunningSystems)
+	as.eq(1, len(info.Attributes.RunningSystems.BuildingJobs))
+	as.eq(`j`, info.Attributes.RunningSystems.BuildingJobs[0].Name)
+	as.eq(`https://x/app.git`, info.Attributes.RunningSystems.MaterialUpdates[0].Attributes[`url`])
+}
+
+func TestServerSetMaintenanceModeSendsConfirmHeader(t *testing.T) {
+	as := asserts(t)
+	rec := &recorder{}
+	c := testClient(t, rec.rt(204, ``))
+
+	as.ok(c.Server.SetMaintenanceMode(context.Background(), true))
+	as.eq(http.MethodPost, rec.req.Method)
+	as.eq(`/go/api/admin/maintenance_mode/enable`, rec.req.URL.Path)
+	as.eq(`true`, rec.req.Header.Get(`X-GoCD-Confirm`))
+
+	as.ok(c.Server.SetMaintenanceMode(context.Background(), false))
+	as.eq(`/go/api/admin/maintenance_mode/disable`, rec.req.URL.Path)
+}
+
+func TestServerBackupFollowsLocation(t *testing.T) {
+	as := asserts(t)
+	rec := &recorder{}
+	c := testClient(t, rec.rt(202, ``, `Location`, `https://proxy.example.com/go/api/backups/7`, `Retry-After`, `5`))
+
+	uri, err := c.Server.Backup(context.Background())
+	as.ok(err)
+	as.eq(`/api/backups/7`, uri)
+	as.eq(http.MethodPost, rec.req.Method)
+	as.eq(`/go/api/backups`, rec.req.URL.Path)
+	as.eq(`true`, rec.req.Header.Get(`X-GoCD-Confirm`))
+	as.eq(`application/vnd.go.cd.v2+json`, rec.req.Header.Get(`Accept`))
+
+	c = testClient(t, rec.rt(200, `{"status":"IN_PROGRESS","progress_status":"BACKUP_DATABASE","message":"Backing up Database","user":{"name":"admin"}}`))
+	backup, err := c.Server.BackupStatus(context.Background(), uri)
+	as.ok(err)
+	as.eq(`/go/api/backups/7`, rec.req.URL.Path)
+	as.eq(`BACKUP_DATABASE`, backup.ProgressStatus)
+	as.eq(`admin`, backup.User.Name)
+	as.not(backup.IsDone())
+
+	_, err = testClient(t, rec.rt(202, ``)).Server.Backup(context.Background())
+	as.err(`The server did not report where to follow the backup`, err)
+}
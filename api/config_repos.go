@@ -0,0 +1,226 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gocd-contrib/gocd-cli/dub"
+)
+
+var (
+	ConfigReposApi   = &Endpoint{`config repos`, []ApiVersion{{4, `20.8.0`, ``}, {3, `19.10.0`, ``}}}
+	ConfigRepoOpsApi = &Endpoint{`config repo operations`, []ApiVersion{{1, `19.1.0`, ``}}}
+)
+
+const (
+	configReposPath   = `/api/admin/config_repos`
+	configRepoOpsPath = `/api/admin/config_repo_ops`
+)
+
+type ConfigRepo struct {
+	Id            string           `json:"id"`
+	PluginId      string           `json:"plugin_id"`
+	Material      Material         `json:"material"`
+	Configuration []ConfigProperty `json:"configuration,omitempty"`
+	Rules         []Rule           `json:"rules,omitempty"`
+
+	// Read-only; reported by the server
+	MaterialUpdateInProgress bool       `json:"material_update_in_progress,omitempty"`
+	ParseInfo                *ParseInfo `json:"parse_info,omitempty"`
+}
+
+// Returns the URL of the repo's material, if it has one
+func (cr *ConfigRepo) Url() string {
+	if u, ok := cr.Material.Attributes[`url`].(string); ok {
+		return u
+	}
+	return ""
+}
+
+// Summarizes the state of the most recent parse: `updating`, `not parsed`,
+// `error`, or `ok`
+func (cr *ConfigRepo) ParseStatus() string {
+	switch {
+	case cr.MaterialUpdateInProgress:
+		return `updating`
+	case nil == cr.ParseInfo || nil == cr.ParseInfo.LatestParsedModification:
+		return `not parsed`
+	case "" != cr.ParseInfo.Error:
+		return `error`
+	default:
+		return `ok`
+	}
+}
+
+// The outcome of parsing a config repo. The good modification is the latest
+// revision that parsed successfully, which is what the server uses when the
+// latest parsed modification has errors.
+type ParseInfo struct {
+	Error                    string        `json:"error,omitempty"`
+	GoodModification         *Modification `json:"good_modification,omitempty"`
+	LatestParsedModification *Modification `json:"latest_parsed_modification,omitempty"`
+}
+
+// Organizes the parse error by file, as reported by the syntax and preflight
+// checks. GoCD reports a plugin's errors as text, with each location on a
+// line of its own followed by `- message` lines; any other text is reported
+// against the config repo as a whole.
+func (p *ParseInfo) Errors(repoId string) *CrResponse {
+	result := &CrResponse{}
+
+	if nil == p {
+		return result
+	}
+
+	location, hasMessages := ``, false
+
+	// a location without messages is likely just a message
+	flush := func() {
+		if "" != location && !hasMessages {
+			result.Errors = append(result.Errors, CrError{File: repoId, Msg: location})
+		}
+	}
+
+	for _, line := range strings.Split(p.Error, "\n") {
+		line = strings.TrimSpace(line)
+
+		switch {
+		case "" == line:
+		case strings.HasPrefix(line, `- `) && "" != location:
+			result.Errors = append(result.Errors, CrError{File: location, Msg: strings.TrimSuffix(strings.TrimPrefix(line, `- `), `;`)})
+			hasMessages = true
+		case strings.HasSuffix(line, `;`) || strings.HasSuffix(line, `:`):
+			flush()
+			location, hasMessages = strings.TrimRight(line, `;:`), false
+		default:
+			result.Errors = append(result.Errors, CrError{File: repoId, Msg: line})
+		}
+	}
+
+	flush()
+	return result
+}
+
+type Modification struct {
+	Username     string `json:"username"`
+	EmailAddress string `json:"email_address,omitempty"`
+	Revision     string `json:"revision"`
+	Comment      string `json:"comment"`
+	ModifiedTime string `json:"modified_time"`
+}
+
+// A material definition. Attributes vary by material type (e.g., `url` and
+// `branch` for git), so they are kept as a map to round-trip faithfully.
+type Material struct {
+	Type       string                 `json:"type"`
+	Attributes map[string]interface{} `json:"attributes"`
+}
+
+// A plugin configuration property; only one of Value or EncryptedValue is
+// set for secure properties
+type ConfigProperty struct {
+	Key            string `json:"key"`
+	Value          string `json:"value,omitempty"`
+	EncryptedValue string `json:"encrypted_value,omitempty"`
+	Secure         bool   `json:"secure,omitempty"`
+}
+
+// Grants or denies a config repo access to entities such as pipeline groups
+type Rule struct {
+	Directive string `json:"directive"`
+	Action    string `json:"action"`
+	Type      string `json:"type"`
+	Resource  string `json:"resource"`
+}
+
+type ConfigRepoStatus struct {
+	InProgress bool `json:"inProgress"`
+}
+
+type CrPreflightOpts struct {
+	PluginId string
+
+	// Optional; preflights the files as a change to an existing config repo
+	RepoId string
+}
+
+type configReposList struct {
+	Embedded struct {
+		ConfigRepos []*ConfigRepo `json:"config_repos"`
+	} `json:"_embedded"`
+}
+
+type ConfigReposService struct {
+	c *Client
+}
+
+func (s *ConfigReposService) List(ctx context.Context) ([]*ConfigRepo, error) {
+	result := &configReposList{}
+	_, err := s.c.do(ctx, ConfigReposApi, http.MethodGet, configReposPath, nil, result)
+	return result.Embedded.ConfigRepos, err
+}
+
+// Fetches a config repo along with its ETag, which is required to update it
+func (s *ConfigReposService) Get(ctx context.Context, id string) (*ConfigRepo, string, error) {
+	result := &ConfigRepo{}
+	headers, err := s.c.do(ctx, ConfigReposApi, http.MethodGet, apiPath(configReposPath, id), nil, result)
+	return result, headers.Get(`ETag`), err
+}
+
+func (s *ConfigReposService) Create(ctx context.Context, repo *ConfigRepo) (*ConfigRepo, error) {
+	result := &ConfigRepo{}
+	_, err := s.c.do(ctx, ConfigReposApi, http.MethodPost, configReposPath, repo, result)
+	return result, err
+}
+
+// Replaces a config repo; etag must be that of the version being replaced,
+// otherwise the server rejects the update with a 412
+func (s *ConfigReposService) Update(ctx context.Context, repo *ConfigRepo, etag string) (*ConfigRepo, string, error) {
+	result := &ConfigRepo{}
+	headers, err := s.c.do(ctx, ConfigReposApi, http.MethodPut, apiPath(configReposPath, repo.Id), repo, result, IfMatch(etag))
+	return result, headers.Get(`ETag`), err
+}
+
+// Deletes a config repo, returning the server's confirmation message
+func (s *ConfigReposService) Delete(ctx context.Context, id string) (string, error) {
+	result := &ApiMessage{}
+	_, err := s.c.do(ctx, ConfigReposApi, http.MethodDelete, apiPath(configReposPath, id), nil, result)
+	return result.String(), err
+}
+
+// Reports whether the server is currently parsing the config repo
+func (s *ConfigReposService) Status(ctx context.Context, id string) (*ConfigRepoStatus, error) {
+	result := &ConfigRepoStatus{}
+	_, err := s.c.do(ctx, ConfigReposApi, http.MethodGet, apiPath(configReposPath, id, `status`), nil, result)
+	return result, err
+}
+
+// Schedules an immediate material update and parse of the config repo
+func (s *ConfigReposService) TriggerUpdate(ctx context.Context, id string) (string, error) {
+	result := &ApiMessage{}
+	_, err := s.c.do(ctx, ConfigReposApi, http.MethodPost, apiPath(configReposPath, id, `trigger_update`), nil, result)
+	return result.String(), err
+}
+
+// Validates definition files against the server without saving them; body
+// is typically a dub.Multipart of `files[]`
+func (s *ConfigReposService) Preflight(ctx context.Context, opts *CrPreflightOpts, body io.Reader) (*CrPreflightResponse, error) {
+	query := url.Values{`pluginId`: {opts.PluginId}}
+
+	if "" != opts.RepoId {
+		query.Add(`repoId`, opts.RepoId)
+	}
+
+	result := &CrPreflightResponse{}
+	b, err := s.c.For(ctx, ConfigRepoOpsApi)
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = b.Post(dub.AddQuery(configRepoOpsPath+`/preflight`, query), body).Decode(ctx, result)
+	return result, err
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"net/http"
+)
+
+var EnvironmentsApi = &Endpoint{`environments`, []ApiVersion{{3, `20.1.0`, ``}}}
+
+const environmentsPath = `/api/admin/environments`
+
+type Environment struct {
+	Name                 string                `json:"name"`
+	Pipelines            []NameRef             `json:"pipelines"`
+	EnvironmentVariables []EnvironmentVariable `json:"environment_variables"`
+}
+
+// Adds a pipeline to the environment, unless it is already there
+func (e *Environment) AddPipeline(name string) {
+	for _, p := range e.Pipelines {
+		if name == p.Name {
+			return
+		}
+	}
+	e.Pipelines = append(e.Pipelines, NameRef{name})
+}
+
+// Removes a pipeline from the environment; reports whether it was there
+func (e *Environment) RemovePipeline(name string) bool {
+	for i, p := range e.Pipelines {
+		if name == p.Name {
+			e.Pipelines = append(e.Pipelines[:i], e.Pipelines[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Adds a variable to the environment, replacing any of the same name
+func (e *Environment) SetVariable(v EnvironmentVariable) {
+	for i, existing := range e.EnvironmentVariables {
+		if v.Name == existing.Name {
+			e.EnvironmentVariables[i] = v
+			return
+		}
+	}
+	e.EnvironmentVariables = append(e.EnvironmentVariables, v)
+}
+
+// Removes a variable from the environment; reports whether it was there
+func (e *Environment) RemoveVariable(name string) bool {
+	for i, v := range e.EnvironmentVariables {
+		if name == v.Name {
+			e.EnvironmentVariables = append(e.EnvironmentVariables[:i], e.EnvironmentVariables[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+type environmentsList struct {
+	Embedded struct {
+		Environments []*Environment `json:"environments"`
+	} `json:"_embedded"`
+}
+
+type EnvironmentsService struct {
+	c *Client
+}
+
+func (s *EnvironmentsService) List(ctx context.Context) ([]*Environment, error) {
+	result := &environmentsList{}
+	_, err := s.c.do(ctx, EnvironmentsApi, http.MethodGet, environmentsPath, nil, result)
+	return result.Embedded.Environments, err
+}
+
+// Fetches an environment along with its ETag, which is required to update it
+func (s *EnvironmentsService) Get(ctx context.Context, name string) (*Environment, string, error) {
+	result := &Environment{}
+	headers, err := s.c.do(ctx, EnvironmentsApi, http.MethodGet, apiPath(environmentsPath, name), nil, result)
+	return result, headers.Get(`ETag`), err
+}
+
+func (s *EnvironmentsService) Create(ctx context.Context, env *Environment) (*Environment, error) {
+	result := &Environment{}
+	_, err := s.c.do(ctx, EnvironmentsApi, http.MethodPost, environmentsPath, env, result)
+	return result, err
+}
+
+// Replaces an environment; etag must be that of the version being replaced
+func (s *EnvironmentsService) Update(ctx context.Context, env *Environment, etag string) (*Environment, string, error) {
+	result := &Environment{}
+	headers, err := s.c.do(ctx, EnvironmentsApi, http.MethodPut, apiPath(environmentsPath, env.Name), env, result, IfMatch(etag))
+	return result, headers.Get(`ETag`), err
+}
+
+func (s *EnvironmentsService) Delete(ctx context.Context, name string) (string, error) {
+	result := &ApiMessage{}
+	_, err := s.c.do(ctx, EnvironmentsApi, http.MethodDelete, apiPath(environmentsPath, name), nil, result)
+	return result.String(), err
+}
@@ -0,0 +1,197 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gocd-contrib/gocd-cli/dub"
+)
+
+const MSG_INVALID_CREDENTIALS = `Invalid credentials. Either the configured username, password, or auth token is incorrect`
+
+// An error response from the GoCD API
+type Error struct {
+	Status int
+	Method string
+	Url    string
+
+	// The `message` from the response body, if any
+	Message string
+
+	// Field-level validation errors (i.e., `data.errors`, as returned on
+	// 422), keyed by field name. Errors on nested objects are keyed by their
+	// dotted path (e.g., `material.attributes.url`).
+	FieldErrors map[string][]string
+
+	// The raw response body
+	Body []byte
+}
+
+func (e *Error) Error() string {
+	msg := e.Message
+
+	if "" == msg {
+		msg = fmt.Sprintf(`Unexpected response %d %s from %s %s`, e.Status, http.StatusText(e.Status), e.Method, e.Url)
+	}
+
+	if len(e.FieldErrors) > 0 {
+		lines := []string{msg}
+
+		for _, field := range e.Fields() {
+			for _, fe := range e.FieldErrors[field] {
+				lines = append(lines, fmt.Sprintf(`  - %s: %s`, field, fe))
+			}
+		}
+
+		return strings.Join(lines, "\n")
+	}
+
+	return msg
+}
+
+// The fields with validation errors, sorted
+func (e *Error) Fields() []string {
+	fields := make([]string, 0, len(e.FieldErrors))
+	for f := range e.FieldErrors {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func (e *Error) IsAuthError() bool {
+	return http.StatusUnauthorized == e.Status || http.StatusForbidden == e.Status
+}
+
+func (e *Error) IsNotFound() bool {
+	return http.StatusNotFound == e.Status
+}
+
+func (e *Error) IsConflict() bool {
+	return http.StatusConflict == e.Status || http.StatusPreconditionFailed == e.Status
+}
+
+func (e *Error) IsUnprocessable() bool {
+	return http.StatusUnprocessableEntity == e.Status
+}
+
+func (e *Error) IsServerError() bool {
+	return e.Status >= 500
+}
+
+// Replaces the message of a 404 with something more specific to the
+// request (e.g., naming the missing resource); GoCD's own 404 message is
+// deliberately vague
+func (e *Error) NotFound(f string, t ...interface{}) *Error {
+	if e.IsNotFound() {
+		e.Message = fmt.Sprintf(f, t...)
+	}
+	return e
+}
+
+// Applies (*Error).NotFound to err if it is an *Error; other errors are
+// returned as-is
+func NotFound(err error, f string, t ...interface{}) error {
+	var e *Error
+	if errors.As(err, &e) {
+		e.NotFound(f, t...)
+	}
+	return err
+}
+
+// Replaces the message of a 412, which the server returns when a resource
+// was modified after the ETag sent in `If-Match` was fetched
+func (e *Error) Modified(f string, t ...interface{}) *Error {
+	if http.StatusPreconditionFailed == e.Status {
+		e.Message = fmt.Sprintf(f, t...)
+	}
+	return e
+}
+
+// Applies (*Error).Modified to err if it is an *Error; other errors are
+// returned as-is
+func Modified(err error, f string, t ...interface{}) error {
+	var e *Error
+	if errors.As(err, &e) {
+		e.Modified(f, t...)
+	}
+	return err
+}
+
+// Reads an error response into an *Error
+func ReadError(res *dub.Response) *Error {
+	e := &Error{Status: res.Status}
+
+	if req := res.Raw.Request; req != nil {
+		e.Method, e.Url = req.Method, req.URL.String()
+	}
+
+	if err := ReadBodyAndDo(res, func(b []byte) error {
+		e.Body = b
+		return nil
+	}); err != nil {
+		e.Message = fmt.Sprintf(`Unexpected response %d %s from %s %s; failed to read body: %v`, e.Status, http.StatusText(e.Status), e.Method, e.Url, err)
+		return e
+	}
+
+	parseErrorBody(e)
+
+	if http.StatusUnauthorized == e.Status {
+		e.Message = MSG_INVALID_CREDENTIALS
+	}
+
+	return e
+}
+
+func parseErrorBody(e *Error) {
+	body := struct {
+		Message string                 `json:"message"`
+		Data    map[string]interface{} `json:"data"`
+	}{}
+
+	if err := json.Unmarshal(e.Body, &body); err != nil {
+		return // not JSON (e.g., an HTML error page from a proxy)
+	}
+
+	e.Message = body.Message
+
+	if nil != body.Data {
+		e.FieldErrors = make(map[string][]string)
+		collectFieldErrors(``, body.Data, e.FieldErrors)
+
+		if 0 == len(e.FieldErrors) {
+			e.FieldErrors = nil
+		}
+	}
+}
+
+// Walks the entity in a 422 response, gathering the `errors` of the entity
+// and any nested objects
+func collectFieldErrors(prefix string, entity map[string]interface{}, result map[string][]string) {
+	for key, val := range entity {
+		switch v := val.(type) {
+		case map[string]interface{}:
+			if `errors` == key {
+				for field, msgs := range v {
+					if list, ok := msgs.([]interface{}); ok {
+						for _, m := range list {
+							result[prefix+field] = append(result[prefix+field], fmt.Sprint(m))
+						}
+					}
+				}
+			} else {
+				collectFieldErrors(prefix+key+`.`, v, result)
+			}
+		case []interface{}:
+			for i, item := range v {
+				if nested, ok := item.(map[string]interface{}); ok {
+					collectFieldErrors(fmt.Sprintf(`%s%s[%d].`, prefix, key, i), nested, result)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/dub"
+)
+
+func TestSendReturnsApiErrorWithoutFailureHandler(t *testing.T) {
+	as := asserts(t)
+
+	err := sendExpectingError(422, `{
+  "message": "Validation error.",
+  "data": {
+    "id": "repo1",
+    "errors": {"id": ["Duplicate id"]},
+    "material": {
+      "attributes": {
+        "errors": {"url": ["URL cannot be blank", "URL must be valid"]}
+      }
+    },
+    "rules": [{"errors": {"directive": ["Invalid directive"]}}]
+  }
+}`)
+
+	var apiErr *api.Error
+	as.is(errors.As(err, &apiErr))
+	as.eq(422, apiErr.Status)
+	as.eq(`GET`, apiErr.Method)
+	as.eq(TEST_API_URL, apiErr.Url)
+	as.eq(`Validation error.`, apiErr.Message)
+	as.is(apiErr.IsUnprocessable())
+
+	as.err(`Validation error.
+  - id: Duplicate id
+  - material.attributes.url: URL cannot be blank
+  - material.attributes.url: URL must be valid
+  - rules[0].directive: Invalid directive`, err)
+}
+
+func TestApiErrorMessages(t *testing.T) {
+	as := asserts(t)
+
+	as.err(`Unexpected response 502 Bad Gateway from GET `+TEST_API_URL, sendExpectingError(502, `<html>proxy error</html>`))
+	as.err(`Pipeline is locked`, sendExpectingError(409, `{"message": "Pipeline is locked"}`))
+	as.err(api.MSG_INVALID_CREDENTIALS, sendExpectingError(401, `{"message": "You are not authenticated!"}`))
+
+	req := &api.Req{
+		Raw:        dub.Make(useRt(404, `{"message": "Either the resource you requested was not found, or you are not authorized to perform this action."}`)).Get(TEST_API_URL),
+		Configurer: &testConfigurer{},
+	}
+
+	as.err(`No such thing: "foo"`, req.Send(context.Background(), nil, func(res *dub.Response) error {
+		return api.ReadError(res).NotFound(`No such thing: %q`, `foo`)
+	}))
+}
+
+func sendExpectingError(status int, body string) error {
+	req := &api.Req{
+		Raw:        dub.Make(useRt(status, body)).Get(TEST_API_URL),
+		Configurer: &testConfigurer{},
+	}
+
+	return req.Send(context.Background(), nil, nil)
+}
+
+func TestModifiedOnlyRewrites412(t *testing.T) {
+	as := asserts(t)
+
+	as.err(`"foo" was changed by someone else`, api.Modified(sendExpectingError(412, `{"message": "Someone has modified the configuration"}`), `%q was changed by someone else`, `foo`))
+	as.err(`Pipeline is locked`, api.Modified(sendExpectingError(409, `{"message": "Pipeline is locked"}`), `%q was changed by someone else`, `foo`))
+}
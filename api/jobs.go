@@ -0,0 +1,134 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gocd-contrib/gocd-cli/dub"
+)
+
+var JobsApi = &Endpoint{`jobs`, []ApiVersion{{1, `19.9.0`, ``}}}
+
+const (
+	jobsPath  = `/api/jobs`
+	filesPath = `/files`
+)
+
+// Identifies a single run of a job
+type JobLocator struct {
+	StageLocator
+	Job string
+}
+
+func (l *JobLocator) String() string {
+	return l.StageLocator.String() + `/` + l.Job
+}
+
+func (l *JobLocator) segments() []string {
+	return append(l.StageLocator.segments(), l.Job)
+}
+
+// A run of a job, as reported by the job instance and history endpoints,
+// and within stage and pipeline runs
+type JobResult struct {
+	Id              int64             `json:"id"`
+	Name            string            `json:"name"`
+	State           string            `json:"state"`
+	Result          string            `json:"result"`
+	ScheduledDate   int64             `json:"scheduled_date"`
+	Rerun           bool              `json:"rerun,omitempty"`
+	AgentUuid       string            `json:"agent_uuid,omitempty"`
+	PipelineName    string            `json:"pipeline_name,omitempty"`
+	PipelineCounter Counter           `json:"pipeline_counter,omitempty"`
+	StageName       string            `json:"stage_name,omitempty"`
+	StageCounter    Counter           `json:"stage_counter,omitempty"`
+	Transitions     []StateTransition `json:"job_state_transitions,omitempty"`
+}
+
+// Reports whether the job has finished, successfully or not
+func (j *JobResult) IsCompleted() bool {
+	return `Completed` == j.State
+}
+
+type StateTransition struct {
+	State           string `json:"state"`
+	StateChangeTime int64  `json:"state_change_time"`
+}
+
+type JobHistory struct {
+	Links Links        `json:"_links"`
+	Jobs  []*JobResult `json:"jobs"`
+}
+
+func (h *JobHistory) PageLinks() Links {
+	return h.Links
+}
+
+type JobsService struct {
+	c *Client
+}
+
+func (s *JobsService) Instance(ctx context.Context, loc *JobLocator) (*JobResult, error) {
+	result := &JobResult{}
+	_, err := s.c.do(ctx, JobsApi, http.MethodGet, apiPath(jobsPath, loc.segments()...), nil, result)
+	return result, err
+}
+
+// Fetches the most recent page of the job's run history across pipeline
+// runs
+func (s *JobsService) History(ctx context.Context, pipeline, stage, job string) (*JobHistory, error) {
+	result := &JobHistory{}
+	err := s.HistoryPages(pipeline, stage, job, 0).Next(ctx, result)
+	return result, err
+}
+
+// Pages through the job's run history, most recent first; pageSize may be 0
+// to use the server's default
+func (s *JobsService) HistoryPages(pipeline, stage, job string, pageSize int) *Paginator {
+	return s.c.paginate(JobsApi, apiPath(jobsPath, pipeline, stage, job, `history`), pageSize)
+}
+
+// Writes the job's console log to w, starting at the given byte offset;
+// returns the offset to continue from once the job has logged more. Fails
+// with a 404 if the job has not started logging yet.
+func (s *JobsService) ConsoleLog(ctx context.Context, loc *JobLocator, offset int64, w io.Writer) (int64, error) {
+	uri := apiPath(filesPath, append(loc.segments(), `cruise-output`, `console.log`)...)
+	hooks := []CreateHook{}
+
+	if offset > 0 {
+		hooks = append(hooks, func(req *dub.Request) error {
+			req.Header(`Range`, `bytes=`+strconv.FormatInt(offset, 10)+`-`)
+			return nil
+		})
+	}
+
+	err := s.c.V(Unversioned).Get(uri, hooks...).Send(ctx, func(res *dub.Response) error {
+		return res.Consume(func(body io.Reader) error {
+			// the server ignored the range; skip what has been written already
+			if http.StatusPartialContent != res.Status && offset > 0 {
+				if _, err := io.CopyN(io.Discard, body, offset); err != nil {
+					if errors.Is(err, io.EOF) {
+						return nil // the log is no longer than before
+					}
+					return err
+				}
+			}
+
+			n, err := io.Copy(w, body)
+			offset += n
+			return err
+		})
+	}, func(res *dub.Response) error {
+		if http.StatusRequestedRangeNotSatisfiable == res.Status {
+			return res.Consume(func(io.Reader) error {
+				return nil // nothing new since the last offset
+			})
+		}
+		return ReadError(res)
+	})
+
+	return offset, err
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A locator counter standing for the latest run; written as `latest`, or
+// omitted for stage counters
+const LatestCounter = 0
+
+// Parses a `pipeline/counter/stage[/counter]` stage locator. Counters may
+// be `latest`, resolved later by Client.Resolve; an omitted stage counter
+// also means the latest.
+func ParseStageLocator(s string) (*StageLocator, error) {
+	parts := strings.Split(s, `/`)
+
+	if len(parts) < 3 || len(parts) > 4 {
+		return nil, fmt.Errorf(`Invalid stage locator %q; expected pipeline/counter/stage[/counter]`, s)
+	}
+
+	return parseStageParts(s, parts)
+}
+
+// Parses a `pipeline/counter/stage[/counter]/job` job locator. Counters may
+// be `latest`, resolved later by Client.Resolve; an omitted stage counter
+// also means the latest.
+func ParseJobLocator(s string) (*JobLocator, error) {
+	parts := strings.Split(s, `/`)
+
+	if len(parts) < 4 || len(parts) > 5 {
+		return nil, fmt.Errorf(`Invalid job locator %q; expected pipeline/counter/stage[/counter]/job`, s)
+	}
+
+	job := parts[len(parts)-1]
+
+	if "" == job {
+		return nil, fmt.Errorf(`Invalid job locator %q; the job name is empty`, s)
+	}
+
+	stage, err := parseStageParts(s, parts[:len(parts)-1])
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &JobLocator{StageLocator: *stage, Job: job}, nil
+}
+
+func parseStageParts(s string, parts []string) (*StageLocator, error) {
+	loc := &StageLocator{Pipeline: parts[0], Stage: parts[2]}
+
+	if "" == loc.Pipeline || "" == loc.Stage {
+		return nil, fmt.Errorf(`Invalid locator %q; pipeline and stage names must not be empty`, s)
+	}
+
+	var err error
+
+	if loc.PipelineCounter, err = parseCounter(s, parts[1]); err != nil {
+		return nil, err
+	}
+
+	if 4 == len(parts) {
+		if loc.StageCounter, err = parseCounter(s, parts[3]); err != nil {
+			return nil, err
+		}
+	}
+
+	return loc, nil
+}
+
+func parseCounter(s, counter string) (int, error) {
+	if `latest` == counter {
+		return LatestCounter, nil
+	}
+
+	if n, err := strconv.Atoi(counter); err == nil && n > 0 {
+		return n, nil
+	}
+
+	return 0, fmt.Errorf(`Invalid counter %q in locator %q; expected a positive number or "latest"`, counter, s)
+}
+
+// Replaces `latest` counters in the locator with the counters of the latest
+// pipeline run, and of the latest run of the stage within it
+func (c *Client) Resolve(ctx context.Context, loc *StageLocator) error {
+	if LatestCounter == loc.PipelineCounter {
+		counter, err := c.Pipelines.LatestCounter(ctx, loc.Pipeline)
+
+		if err != nil {
+			return err
+		}
+
+		loc.PipelineCounter = counter
+	}
+
+	if LatestCounter == loc.StageCounter {
+		run, err := c.Pipelines.Instance(ctx, loc.Pipeline, loc.PipelineCounter)
+
+		if err != nil {
+			return NotFound(err, `No such pipeline run: %s/%d`, loc.Pipeline, loc.PipelineCounter)
+		}
+
+		if s := run.Stage(loc.Stage); nil != s && s.Scheduled {
+			loc.StageCounter = int(s.Counter)
+		} else {
+			return fmt.Errorf(`Stage %q has not run in %s/%d`, loc.Stage, loc.Pipeline, loc.PipelineCounter)
+		}
+	}
+
+	return nil
+}
+
+// Parses a stage locator, and resolves any `latest` counters
+func (c *Client) LocateStage(ctx context.Context, locator string) (*StageLocator, error) {
+	loc, err := ParseStageLocator(locator)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return loc, c.Resolve(ctx, loc)
+}
+
+// Parses a job locator, and resolves any `latest` counters
+func (c *Client) LocateJob(ctx context.Context, locator string) (*JobLocator, error) {
+	loc, err := ParseJobLocator(locator)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return loc, c.Resolve(ctx, &loc.StageLocator)
+}
@@ -0,0 +1,101 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+)
+
+func TestParseStageLocator(t *testing.T) {
+	as := asserts(t)
+
+	loc, err := api.ParseStageLocator(`p/3/s/2`)
+	as.ok(err)
+	as.eq(`p/3/s/2`, loc.String())
+
+	loc, err = api.ParseStageLocator(`p/latest/s`)
+	as.ok(err)
+	as.eq(api.LatestCounter, loc.PipelineCounter)
+	as.eq(api.LatestCounter, loc.StageCounter)
+
+	loc, err = api.ParseStageLocator(`p/3/s/latest`)
+	as.ok(err)
+	as.eq(3, loc.PipelineCounter)
+	as.eq(api.LatestCounter, loc.StageCounter)
+
+	_, err = api.ParseStageLocator(`p/3`)
+	as.err(`Invalid stage locator "p/3"; expected pipeline/counter/stage[/counter]`, err)
+
+	_, err = api.ParseStageLocator(`p/0/s`)
+	as.err(`Invalid counter "0" in locator "p/0/s"; expected a positive number or "latest"`, err)
+
+	_, err = api.ParseStageLocator(`/3/s`)
+	as.err(`Invalid locator "/3/s"; pipeline and stage names must not be empty`, err)
+}
+
+func TestParseJobLocator(t *testing.T) {
+	as := asserts(t)
+
+	loc, err := api.ParseJobLocator(`p/3/s/2/j`)
+	as.ok(err)
+	as.eq(`p/3/s/2/j`, loc.String())
+
+	loc, err = api.ParseJobLocator(`p/latest/s/j`)
+	as.ok(err)
+	as.eq(`p`, loc.Pipeline)
+	as.eq(`s`, loc.Stage)
+	as.eq(`j`, loc.Job)
+	as.eq(api.LatestCounter, loc.StageCounter)
+
+	_, err = api.ParseJobLocator(`p/3/s`)
+	as.err(`Invalid job locator "p/3/s"; expected pipeline/counter/stage[/counter]/job`, err)
+
+	_, err = api.ParseJobLocator(`p/3/s/x/j`)
+	as.err(`Invalid counter "x" in locator "p/3/s/x/j"; expected a positive number or "latest"`, err)
+
+	_, err = api.ParseJobLocator(`p/3/s/`)
+	as.err(`Invalid job locator "p/3/s/"; the job name is empty`, err)
+}
+
+func TestClientResolvesLatestCounters(t *testing.T) {
+	as := asserts(t)
+
+	c := testClient(t, mockRT(func(rq *http.Request) (*http.Response, error) {
+		var rs *http.Response
+
+		switch {
+		case strings.HasSuffix(rq.URL.Path, `/history`):
+			rs = resp(200, `{"pipelines":[{"name":"p","counter":7},{"name":"p","counter":6}]}`)
+		case strings.HasSuffix(rq.URL.Path, `/api/pipelines/p/7`):
+			rs = resp(200, `{"name":"p","counter":7,"stages":[{"name":"build","counter":"2","scheduled":true},{"name":"deploy","scheduled":false}]}`)
+		default:
+			rs = resp(404, `{"message":"nope"}`)
+		}
+
+		rs.Request = rq
+		return rs, nil
+	}))
+
+	loc, err := api.ParseStageLocator(`p/latest/build`)
+	as.ok(err)
+	as.ok(c.Resolve(context.Background(), loc))
+	as.eq(`p/7/build/2`, loc.String())
+
+	loc, err = api.ParseStageLocator(`p/7/deploy/latest`)
+	as.ok(err)
+	as.err(`Stage "deploy" has not run in p/7`, c.Resolve(context.Background(), loc))
+
+	loc, err = api.ParseStageLocator(`p/5/build`)
+	as.ok(err)
+	as.err(`No such pipeline run: p/5`, c.Resolve(context.Background(), loc))
+
+	job, err := c.LocateJob(context.Background(), `p/latest/build/latest/compile`)
+	as.ok(err)
+	as.eq(`p/7/build/2/compile`, job.String())
+
+	_, err = c.LocateJob(context.Background(), `p/latest/build`)
+	as.err(`Invalid job locator "p/latest/build"; expected pipeline/counter/stage[/counter]/job`, err)
+}
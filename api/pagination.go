@@ -0,0 +1,115 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gocd-contrib/gocd-cli/dub"
+)
+
+// HAL links, as included in paginated responses
+type Links struct {
+	Next     *Link `json:"next,omitempty"`
+	Previous *Link `json:"previous,omitempty"`
+}
+
+type Link struct {
+	Href string `json:"href"`
+}
+
+// A page of a paginated list response
+type Page interface {
+	PageLinks() Links
+}
+
+// Walks the pages of a paginated list endpoint by following each page's
+// `next` link, in the style of bufio.Scanner:
+//
+//	pages := client.Pipelines.HistoryPages(`up42`, 0)
+//	for pages.More() {
+//		page := &api.PipelineHistory{}
+//		if err := pages.Next(ctx, page); err != nil {
+//			return err
+//		}
+//		...
+//	}
+type Paginator struct {
+	c        *Client
+	ep       *Endpoint
+	pageSize int
+	next     string // the URI of the next page; empty when exhausted
+}
+
+func (c *Client) paginate(ep *Endpoint, uri string, pageSize int) *Paginator {
+	p := &Paginator{c: c, ep: ep, pageSize: pageSize}
+	p.next = p.withPageSize(uri)
+	return p
+}
+
+// Requests the page size unless the URI already does; the server does not
+// always carry it over into its links
+func (p *Paginator) withPageSize(uri string) string {
+	if p.pageSize <= 0 || strings.Contains(uri, `page_size=`) {
+		return uri
+	}
+	return dub.AddQuery(uri, url.Values{`page_size`: {strconv.Itoa(p.pageSize)}})
+}
+
+// Reports whether there are more pages to fetch
+func (p *Paginator) More() bool {
+	return "" != p.next
+}
+
+// Fetches the next page into page
+func (p *Paginator) Next(ctx context.Context, page Page) error {
+	if !p.More() {
+		return errors.New(`no more pages`)
+	}
+
+	if _, err := p.c.do(ctx, p.ep, http.MethodGet, p.next, nil, page); err != nil {
+		return err
+	}
+
+	p.next = ""
+
+	if link := page.PageLinks().Next; nil != link && "" != link.Href {
+		uri, err := relativeApiUri(link.Href)
+
+		if err != nil {
+			return err
+		}
+
+		p.next = p.withPageSize(uri)
+	}
+
+	return nil
+}
+
+// Converts an absolute link from the server into a URI relative to the
+// server URL, as the server may report a different host or scheme (e.g.,
+// behind a proxy) than the one configured
+func relativeApiUri(href string) (string, error) {
+	u, err := url.Parse(href)
+
+	if err != nil {
+		return "", err
+	}
+
+	i := strings.Index(u.Path, `/api/`)
+
+	if i < 0 {
+		return "", errors.New(`This is not an API URL: ` + href)
+	}
+
+	uri := u.Path[i:]
+
+	if "" != u.RawQuery {
+		uri += `?` + u.RawQuery
+	}
+
+	return uri, nil
+}
@@ -0,0 +1,64 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+)
+
+func TestPaginatorFollowsNextLinks(t *testing.T) {
+	as := asserts(t)
+	var uris []string
+
+	c := testClient(t, mockRT(func(rq *http.Request) (*http.Response, error) {
+		uris = append(uris, rq.URL.RequestURI())
+
+		var rs *http.Response
+
+		switch rq.URL.Query().Get(`after`) {
+		case ``:
+			rs = resp(200, `{"_links":{"next":{"href":"https://proxy.example.com/go/api/pipelines/p/history?after=4"}},"pipelines":[{"name":"p","counter":5},{"name":"p","counter":4}]}`)
+		case `4`:
+			rs = resp(200, `{"_links":{"previous":{"href":"http://test/go/api/pipelines/p/history?before=4"}},"pipelines":[{"name":"p","counter":3}]}`)
+		default:
+			rs = resp(404, `{"message":"nope"}`)
+		}
+
+		rs.Request = rq
+		return rs, nil
+	}))
+
+	pages := c.Pipelines.HistoryPages(`p`, 2)
+	var counters []int
+
+	for pages.More() {
+		page := &api.PipelineHistory{}
+		as.ok(pages.Next(context.Background(), page))
+
+		for _, run := range page.Pipelines {
+			counters = append(counters, run.Counter)
+		}
+	}
+
+	as.eq(3, len(counters))
+	as.eq(5, counters[0])
+	as.eq(3, counters[2])
+
+	as.eq(2, len(uris))
+	as.eq(`/go/api/pipelines/p/history?page_size=2`, uris[0])
+	as.eq(`/go/api/pipelines/p/history?after=4&page_size=2`, uris[1])
+
+	as.err(`no more pages`, pages.Next(context.Background(), &api.PipelineHistory{}))
+}
+
+func TestPaginatorRejectsNonApiLinks(t *testing.T) {
+	as := asserts(t)
+	rec := &recorder{}
+	c := testClient(t, rec.rt(200, `{"_links":{"next":{"href":"http://test/go/pipelines/p"}},"jobs":[]}`))
+
+	err := c.Jobs.HistoryPages(`p`, `s`, `j`, 0).Next(context.Background(), &api.JobHistory{})
+	as.err(`This is not an API URL: http://test/go/pipelines/p`, err)
+	as.eq(`/go/api/jobs/p/s/j/history`, rec.req.URL.RequestURI())
+}
@@ -0,0 +1,184 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var PipelineGroupsApi = &Endpoint{`pipeline groups`, []ApiVersion{{1, `19.5.0`, ``}}}
+
+const pipelineGroupsPath = `/api/admin/pipeline_groups`
+
+type PipelineGroup struct {
+	Name          string              `json:"name"`
+	Authorization *GroupAuthorization `json:"authorization,omitempty"`
+	Pipelines     []NameRef           `json:"pipelines,omitempty"`
+}
+
+// The users and roles granted each permission on a pipeline group
+type GroupAuthorization struct {
+	View    *Grantees `json:"view,omitempty"`
+	Operate *Grantees `json:"operate,omitempty"`
+	Admins  *Grantees `json:"admins,omitempty"`
+}
+
+type Grantees struct {
+	Users []string `json:"users"`
+	Roles []string `json:"roles"`
+}
+
+// Permissions on a pipeline group, as named on the command line
+const (
+	PermissionView    = `view`
+	PermissionOperate = `operate`
+	PermissionAdmin   = `admin`
+)
+
+// Returns the grantees of a permission, creating them if create is set and
+// there are none yet
+func (g *PipelineGroup) grantees(permission string, create bool) (*Grantees, error) {
+	if nil == g.Authorization {
+		if !create {
+			return nil, nil
+		}
+		g.Authorization = &GroupAuthorization{}
+	}
+
+	var grantees **Grantees
+
+	switch permission {
+	case PermissionView:
+		grantees = &g.Authorization.View
+	case PermissionOperate:
+		grantees = &g.Authorization.Operate
+	case PermissionAdmin:
+		grantees = &g.Authorization.Admins
+	default:
+		return nil, fmt.Errorf(`Unknown permission %q; expected %s, %s, or %s`, permission, PermissionView, PermissionOperate, PermissionAdmin)
+	}
+
+	if nil == *grantees && create {
+		*grantees = &Grantees{Users: []string{}, Roles: []string{}}
+	}
+
+	return *grantees, nil
+}
+
+// Grants a permission on the group to users and roles
+func (g *PipelineGroup) Grant(permission string, users, roles []string) error {
+	grantees, err := g.grantees(permission, true)
+
+	if err != nil {
+		return err
+	}
+
+	grantees.Users = addName(grantees.Users, users)
+	grantees.Roles = addName(grantees.Roles, roles)
+	return nil
+}
+
+// Revokes a permission on the group from users and roles
+func (g *PipelineGroup) Revoke(permission string, users, roles []string) error {
+	grantees, err := g.grantees(permission, false)
+
+	if err != nil || nil == grantees {
+		return err
+	}
+
+	grantees.Users = removeName(grantees.Users, users)
+	grantees.Roles = removeName(grantees.Roles, roles)
+	return nil
+}
+
+// Returns the users and roles granted a permission on the group
+func (g *PipelineGroup) Grantees(permission string) (users, roles []string) {
+	if grantees, _ := g.grantees(permission, false); nil != grantees {
+		return grantees.Users, grantees.Roles
+	}
+	return nil, nil
+}
+
+// Whether any user or role is granted any permission on the group; GoCD
+// opens a group without any to every user
+func (g *PipelineGroup) IsRestricted() bool {
+	for _, p := range []string{PermissionView, PermissionOperate, PermissionAdmin} {
+		if users, roles := g.Grantees(p); len(users)+len(roles) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// GoCD compares user and role names case-insensitively
+func indexOfName(names []string, name string) int {
+	for i, n := range names {
+		if strings.EqualFold(name, n) {
+			return i
+		}
+	}
+	return -1
+}
+
+func addName(names, add []string) []string {
+	for _, name := range add {
+		if indexOfName(names, name) < 0 {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func removeName(names, remove []string) []string {
+	for _, name := range remove {
+		if i := indexOfName(names, name); i >= 0 {
+			names = append(names[:i], names[i+1:]...)
+		}
+	}
+	return names
+}
+
+type pipelineGroupsList struct {
+	Embedded struct {
+		Groups []*PipelineGroup `json:"groups"`
+	} `json:"_embedded"`
+}
+
+type PipelineGroupsService struct {
+	c *Client
+}
+
+func (s *PipelineGroupsService) List(ctx context.Context) ([]*PipelineGroup, error) {
+	result := &pipelineGroupsList{}
+	_, err := s.c.do(ctx, PipelineGroupsApi, http.MethodGet, pipelineGroupsPath, nil, result)
+	return result.Embedded.Groups, err
+}
+
+// Fetches a pipeline group along with its ETag, which is required to update
+// it
+func (s *PipelineGroupsService) Get(ctx context.Context, name string) (*PipelineGroup, string, error) {
+	result := &PipelineGroup{}
+	headers, err := s.c.do(ctx, PipelineGroupsApi, http.MethodGet, apiPath(pipelineGroupsPath, name), nil, result)
+	return result, headers.Get(`ETag`), err
+}
+
+func (s *PipelineGroupsService) Create(ctx context.Context, group *PipelineGroup) (*PipelineGroup, error) {
+	result := &PipelineGroup{}
+	_, err := s.c.do(ctx, PipelineGroupsApi, http.MethodPost, pipelineGroupsPath, group, result)
+	return result, err
+}
+
+// Replaces a pipeline group; etag must be that of the version being replaced
+func (s *PipelineGroupsService) Update(ctx context.Context, group *PipelineGroup, etag string) (*PipelineGroup, string, error) {
+	result := &PipelineGroup{}
+	headers, err := s.c.do(ctx, PipelineGroupsApi, http.MethodPut, apiPath(pipelineGroupsPath, group.Name), group, result, IfMatch(etag))
+	return result, headers.Get(`ETag`), err
+}
+
+// Deletes a pipeline group; the server refuses if it still has pipelines
+func (s *PipelineGroupsService) Delete(ctx context.Context, name string) (string, error) {
+	result := &ApiMessage{}
+	_, err := s.c.do(ctx, PipelineGroupsApi, http.MethodDelete, apiPath(pipelineGroupsPath, name), nil, result)
+	return result.String(), err
+}
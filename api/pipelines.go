@@ -0,0 +1,246 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gocd-contrib/gocd-cli/dub"
+)
+
+var (
+	PipelinesApi = &Endpoint{`pipelines`, []ApiVersion{{1, `19.9.0`, ``}}}
+	ExportApi    = &Endpoint{`export`, []ApiVersion{{1, `18.10.0`, ``}}}
+)
+
+const (
+	pipelinesPath = `/api/pipelines`
+	exportPath    = `/api/admin/export/pipelines`
+)
+
+type PipelineStatus struct {
+	Paused      bool   `json:"paused"`
+	PausedCause string `json:"paused_cause"`
+	PausedBy    string `json:"paused_by"`
+	Locked      bool   `json:"locked"`
+	Schedulable bool   `json:"schedulable"`
+}
+
+// A run of a pipeline, as reported by the history and instance endpoints
+type PipelineInstance struct {
+	Name                string         `json:"name"`
+	Counter             int            `json:"counter"`
+	Label               string         `json:"label"`
+	NaturalOrder        float64        `json:"natural_order"`
+	CanRun              bool           `json:"can_run"`
+	PreparingToSchedule bool           `json:"preparing_to_schedule"`
+	Comment             string         `json:"comment,omitempty"`
+	ScheduledDate       int64          `json:"scheduled_date"`
+	BuildCause          BuildCause     `json:"build_cause"`
+	Stages              []*StageResult `json:"stages"`
+}
+
+// Returns the named stage of this run, or nil if it has not been scheduled
+func (p *PipelineInstance) Stage(name string) *StageResult {
+	for _, s := range p.Stages {
+		if name == s.Name {
+			return s
+		}
+	}
+	return nil
+}
+
+// Reports whether the run has finished, and its result so far: the result
+// of the first stage that did not pass, or `Passed`. A run also finishes
+// when it reaches a stage awaiting manual approval.
+func (p *PipelineInstance) Outcome() (done bool, result string) {
+	if p.PreparingToSchedule {
+		return false, ``
+	}
+
+	for _, s := range p.Stages {
+		switch {
+		case !s.Scheduled:
+			return `manual` == s.ApprovalType, `Passed`
+		case !s.IsCompleted():
+			return false, ``
+		case `Passed` != s.Result:
+			return true, s.Result
+		}
+	}
+
+	return true, `Passed`
+}
+
+type BuildCause struct {
+	Message           string             `json:"trigger_message"`
+	TriggerForced     bool               `json:"trigger_forced"`
+	Approver          string             `json:"approver"`
+	MaterialRevisions []MaterialRevision `json:"material_revisions"`
+}
+
+type MaterialRevision struct {
+	Changed  bool `json:"changed"`
+	Material struct {
+		Name        string `json:"name"`
+		Fingerprint string `json:"fingerprint"`
+		Type        string `json:"type"`
+		Description string `json:"description"`
+	} `json:"material"`
+	Modifications []struct {
+		Revision     string `json:"revision"`
+		UserName     string `json:"user_name"`
+		Comment      string `json:"comment"`
+		ModifiedTime int64  `json:"modified_time"`
+	} `json:"modifications"`
+}
+
+type PipelineHistory struct {
+	Links     Links               `json:"_links"`
+	Pipelines []*PipelineInstance `json:"pipelines"`
+}
+
+func (h *PipelineHistory) PageLinks() Links {
+	return h.Links
+}
+
+type ScheduleOptions struct {
+	EnvironmentVariables []EnvironmentVariable `json:"environment_variables,omitempty"`
+	Materials            []ScheduleMaterial    `json:"materials,omitempty"`
+
+	// Defaults to true on the server when omitted
+	UpdateMaterialsBeforeScheduling *bool `json:"update_materials_before_scheduling,omitempty"`
+}
+
+// Pins a material to a specific revision when scheduling a pipeline
+type ScheduleMaterial struct {
+	Fingerprint string `json:"fingerprint"`
+	Revision    string `json:"revision"`
+}
+
+type EnvironmentVariable struct {
+	Name           string `json:"name"`
+	Value          string `json:"value,omitempty"`
+	EncryptedValue string `json:"encrypted_value,omitempty"`
+	Secure         bool   `json:"secure"`
+}
+
+// Parses a NAME=VALUE environment variable, as given on the command line
+func ParseEnvironmentVariable(spec string, secure bool) (EnvironmentVariable, error) {
+	kv := strings.SplitN(spec, `=`, 2)
+
+	if 2 != len(kv) || "" == kv[0] {
+		return EnvironmentVariable{}, fmt.Errorf(`Invalid environment variable %q; expected NAME=VALUE`, spec)
+	}
+
+	return EnvironmentVariable{Name: kv[0], Value: kv[1], Secure: secure}, nil
+}
+
+// The result of exporting a pipeline as a config repo definition
+type PipelineExport struct {
+	Filename string
+	Content  []byte
+}
+
+type PipelinesService struct {
+	c *Client
+}
+
+func (s *PipelinesService) Status(ctx context.Context, name string) (*PipelineStatus, error) {
+	result := &PipelineStatus{}
+	_, err := s.c.do(ctx, PipelinesApi, http.MethodGet, apiPath(pipelinesPath, name, `status`), nil, result)
+	return result, err
+}
+
+// Triggers a pipeline run; opts may be nil
+func (s *PipelinesService) Schedule(ctx context.Context, name string, opts *ScheduleOptions) (string, error) {
+	if nil == opts {
+		opts = &ScheduleOptions{}
+	}
+
+	result := &ApiMessage{}
+	_, err := s.c.do(ctx, PipelinesApi, http.MethodPost, apiPath(pipelinesPath, name, `schedule`), opts, result)
+	return result.String(), err
+}
+
+func (s *PipelinesService) Pause(ctx context.Context, name, cause string) (string, error) {
+	result := &ApiMessage{}
+	_, err := s.c.do(ctx, PipelinesApi, http.MethodPost, apiPath(pipelinesPath, name, `pause`), map[string]string{`pause_cause`: cause}, result)
+	return result.String(), err
+}
+
+func (s *PipelinesService) Unpause(ctx context.Context, name string) (string, error) {
+	result := &ApiMessage{}
+	_, err := s.c.do(ctx, PipelinesApi, http.MethodPost, apiPath(pipelinesPath, name, `unpause`), nil, result)
+	return result.String(), err
+}
+
+// Releases the lock on a pipeline that is configured to run one instance at
+// a time
+func (s *PipelinesService) Unlock(ctx context.Context, name string) (string, error) {
+	result := &ApiMessage{}
+	_, err := s.c.do(ctx, PipelinesApi, http.MethodPost, apiPath(pipelinesPath, name, `unlock`), nil, result)
+	return result.String(), err
+}
+
+func (s *PipelinesService) Instance(ctx context.Context, name string, counter int) (*PipelineInstance, error) {
+	result := &PipelineInstance{}
+	_, err := s.c.do(ctx, PipelinesApi, http.MethodGet, apiPath(pipelinesPath, name, strconv.Itoa(counter)), nil, result)
+	return result, err
+}
+
+// Fetches the most recent page of the pipeline's run history
+func (s *PipelinesService) History(ctx context.Context, name string) (*PipelineHistory, error) {
+	result := &PipelineHistory{}
+	err := s.HistoryPages(name, 0).Next(ctx, result)
+	return result, err
+}
+
+// Pages through the pipeline's run history, most recent first; pageSize may
+// be 0 to use the server's default
+func (s *PipelinesService) HistoryPages(name string, pageSize int) *Paginator {
+	return s.c.paginate(PipelinesApi, apiPath(pipelinesPath, name, `history`), pageSize)
+}
+
+// Returns the counter of the pipeline's latest run
+func (s *PipelinesService) LatestCounter(ctx context.Context, name string) (int, error) {
+	history, err := s.History(ctx, name)
+
+	if err != nil {
+		return 0, NotFound(err, `No such pipeline: %q`, name)
+	}
+
+	if 0 == len(history.Pipelines) {
+		return 0, fmt.Errorf(`Pipeline %q has never run`, name)
+	}
+
+	return history.Pipelines[0].Counter, nil
+}
+
+// Exports a pipeline's config in the format of the given config repo plugin
+func (s *PipelinesService) Export(ctx context.Context, name, pluginId string) (*PipelineExport, error) {
+	uri := dub.AddQuery(apiPath(exportPath, name), url.Values{`plugin_id`: {pluginId}})
+	result := &PipelineExport{}
+	b, err := s.c.For(ctx, ExportApi)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.Get(uri).Send(ctx, func(res *dub.Response) error {
+		if _, params, err := mime.ParseMediaType(res.Headers.Get(`Content-Disposition`)); err == nil {
+			result.Filename = params[`filename`]
+		}
+
+		return ReadBodyAndDo(res, func(b []byte) error {
+			result.Content = b
+			return nil
+		})
+	}, nil)
+
+	return result, err
+}
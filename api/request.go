@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"errors"
 	"io"
 	"net/url"
@@ -24,7 +25,10 @@ type Req struct {
 	Configurer RequestConfigurer
 }
 
-func (r *Req) Send(onResponse, onErrorResponse func(*dub.Response) error) error {
+// Sends the request; canceling the context (e.g., on timeout or Ctrl-C)
+// aborts it, in which case the context's cause is returned. Error responses
+// are passed to onErrorResponse; if that is nil, Send returns an *Error.
+func (r *Req) Send(ctx context.Context, onResponse, onErrorResponse func(*dub.Response) error) error {
 	if err := r.Config(); err != nil {
 		return utils.InspectError(err, `configuring api.Req during Send()`)
 	} else {
@@ -34,8 +38,7 @@ func (r *Req) Send(onResponse, onErrorResponse func(*dub.Response) error) error
 				if onErrorResponse != nil {
 					return onErrorResponse(res)
 				}
-				utils.Debug(`error response handler is nil; ignoring response`)
-				return nil
+				return ReadError(res)
 			}
 			utils.Debug(`handling success response %d`, res.Status)
 			if onResponse != nil {
@@ -45,8 +48,7 @@ func (r *Req) Send(onResponse, onErrorResponse func(*dub.Response) error) error
 			return nil
 		}
 
-		traceRequest(r.Raw)
-		return utils.InspectError(r.Raw.Do(handleResponse), `making api %s request to %q`, r.Raw.Method, r.Raw.Url)
+		return utils.InspectError(r.Raw.Do(ctx, handleResponse), `making api %s request to %q`, r.Raw.Method, r.Raw.Url)
 	}
 }
 
@@ -60,7 +62,7 @@ func (r *Req) ValidateUrl() error {
 			return errors.New("API URL is not absolute; make sure you have configured `server-url`")
 		}
 
-		if !strings.HasPrefix(u.Path, `/go/api/`) {
+		if !strings.HasPrefix(u.Path, `/go/api/`) && !strings.HasPrefix(u.Path, `/go/files/`) {
 			return errors.New(`This is not an API URL: ` + u.String())
 		}
 	} else {
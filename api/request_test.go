@@ -1,16 +1,18 @@
 package api_test
 
 import (
+	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gocd-contrib/gocd-cli/api"
 	"github.com/gocd-contrib/gocd-cli/dub"
 )
 
-const (
-	TEST_API_URL = `http://test/go/api/doit`
-)
+const TEST_API_URL = `http://test/go/api/doit`
 
 func TestRequestValidateUrl(t *testing.T) {
 	as := asserts(t)
@@ -83,7 +85,7 @@ func TestSendWithSuccessResponse(t *testing.T) {
 		return nil
 	}
 
-	as.ok(req.Send(onSuccess, onFail))
+	as.ok(req.Send(context.Background(), onSuccess, onFail))
 	as.is(didRun)
 }
 
@@ -107,7 +109,7 @@ func TestSendWithFailureResponse(t *testing.T) {
 		return nil
 	}
 
-	as.ok(req.Send(onSuccess, onFail))
+	as.ok(req.Send(context.Background(), onSuccess, onFail))
 	as.is(didRun)
 }
 
@@ -130,6 +132,26 @@ func TestAbortsSendByReturningErrorOnCreate(t *testing.T) {
 		return nil
 	}
 
-	as.err(`Stop this request!`, req.Send(onResponse, onResponse))
+	as.err(`Stop this request!`, req.Send(context.Background(), onResponse, onResponse))
 	as.not(didRun)
 }
+
+func TestSendReturnsCauseWhenContextIsCanceled(t *testing.T) {
+	as := asserts(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	cause := errors.New(`Timed out`)
+	ctx, cancel := context.WithTimeoutCause(context.Background(), 50*time.Millisecond, cause)
+	defer cancel()
+
+	req := &api.Req{
+		Raw:        dub.New().Get(srv.URL + `/go/api/doit`),
+		Configurer: &testConfigurer{},
+	}
+
+	as.eq(cause, req.Send(ctx, nil, nil))
+}
@@ -0,0 +1,159 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gocd-contrib/gocd-cli/dub"
+)
+
+var (
+	ServerHealthMessagesApi = &Endpoint{`server health messages`, []ApiVersion{{1, `18.2.0`, ``}}}
+	MaintenanceModeApi      = &Endpoint{`maintenance mode`, []ApiVersion{{1, `19.1.0`, ``}}}
+	BackupApi               = &Endpoint{`backups`, []ApiVersion{{2, `19.3.0`, ``}}}
+)
+
+const (
+	// Not negotiated; the version is part of the path
+	healthPath               = `/api/v1/health`
+	serverHealthMessagesPath = `/api/server_health_messages`
+	maintenanceModePath      = `/api/admin/maintenance_mode`
+	backupsPath              = `/api/backups`
+)
+
+type ServerHealth struct {
+	Health string `json:"health"`
+}
+
+// A problem the server reports, e.g., a failing material update
+type ServerHealthMessage struct {
+	Message string `json:"message"`
+	Detail  string `json:"detail"`
+	Level   string `json:"level"`
+	Time    string `json:"time"`
+}
+
+// Whether the server is in maintenance mode, and what is still running
+type MaintenanceModeInfo struct {
+	Enabled  bool `json:"is_maintenance_mode"`
+	Metadata struct {
+		UpdatedBy string `json:"updated_by"`
+		UpdatedOn string `json:"updated_on"`
+	} `json:"metadata"`
+	Attributes *struct {
+		HasRunningSystems bool           `json:"has_running_systems"`
+		RunningSystems    RunningSystems `json:"running_systems"`
+	} `json:"attributes,omitempty"`
+}
+
+// The subsystems that keep the server from shutting down safely
+type RunningSystems struct {
+	MaterialUpdates []*MaterialUpdate `json:"material_update_in_progress"`
+	BuildingJobs    []*JobResult      `json:"building_jobs"`
+	ScheduledJobs   []*JobResult      `json:"scheduled_jobs"`
+}
+
+// A material update (MDU) in progress
+type MaterialUpdate struct {
+	Type       string                 `json:"type"`
+	Attributes map[string]interface{} `json:"attributes"`
+	StartTime  string                 `json:"mdu_start_time"`
+}
+
+// The states of a backup
+const (
+	BackupInProgress = `IN_PROGRESS`
+	BackupCompleted  = `COMPLETED`
+	BackupError      = `ERROR`
+	BackupAborted    = `ABORTED`
+)
+
+type Backup struct {
+	Status string `json:"status"`
+
+	// The step the backup is at, e.g., BACKUP_DATABASE; Message describes it
+	ProgressStatus string  `json:"progress_status"`
+	Message        string  `json:"message"`
+	Time           string  `json:"time"`
+	Path           string  `json:"path"`
+	User           NameRef `json:"user"`
+}
+
+func (b *Backup) IsDone() bool {
+	return BackupInProgress != b.Status
+}
+
+func (b *Backup) IsSuccessful() bool {
+	return BackupCompleted == b.Status
+}
+
+type ServerService struct {
+	c *Client
+}
+
+// Checks that the server is up and able to serve requests
+func (s *ServerService) Health(ctx context.Context) (*ServerHealth, error) {
+	result := &ServerHealth{}
+	_, err := s.c.V(Unversioned).Get(healthPath).Decode(ctx, result)
+	return result, err
+}
+
+func (s *ServerService) HealthMessages(ctx context.Context) ([]*ServerHealthMessage, error) {
+	var result []*ServerHealthMessage
+	_, err := s.c.do(ctx, ServerHealthMessagesApi, http.MethodGet, serverHealthMessagesPath, nil, &result)
+	return result, err
+}
+
+func (s *ServerService) MaintenanceMode(ctx context.Context) (*MaintenanceModeInfo, error) {
+	result := &MaintenanceModeInfo{}
+	_, err := s.c.do(ctx, MaintenanceModeApi, http.MethodGet, maintenanceModePath+`/info`, nil, result)
+	return result, err
+}
+
+// Turns maintenance mode on or off; in maintenance mode, the server finishes
+// running builds, but schedules no new ones
+func (s *ServerService) SetMaintenanceMode(ctx context.Context, enabled bool) error {
+	action := `/disable`
+	if enabled {
+		action = `/enable`
+	}
+
+	_, err := s.c.do(ctx, MaintenanceModeApi, http.MethodPost, maintenanceModePath+action, nil, nil)
+	return err
+}
+
+// Starts a backup, returning the URI at which to follow its progress
+func (s *ServerService) Backup(ctx context.Context) (string, error) {
+	b, err := s.c.For(ctx, BackupApi)
+
+	if err != nil {
+		return "", err
+	}
+
+	var location string
+
+	err = b.Post(backupsPath, nil).Send(ctx, func(res *dub.Response) error {
+		// the server answers 202 Accepted with the backup's Location
+		location = res.Headers.Get(`Location`)
+		return res.Consume(func(io.Reader) error { return nil })
+	}, nil)
+
+	if err != nil {
+		return "", err
+	}
+
+	if "" == location {
+		return "", errors.New(`The server did not report where to follow the backup`)
+	}
+
+	return relativeApiUri(location)
+}
+
+// Fetches the progress of a backup at the URI returned by Backup
+func (s *ServerService) BackupStatus(ctx context.Context, uri string) (*Backup, error) {
+	result := &Backup{}
+	_, err := s.c.do(ctx, BackupApi, http.MethodGet, uri, nil, result)
+	return result, err
+}
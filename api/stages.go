@@ -0,0 +1,115 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var (
+	StagesApi   = &Endpoint{`stages`, []ApiVersion{{3, `20.1.0`, ``}}}
+	StageRunApi = &Endpoint{`stage run`, []ApiVersion{{2, `19.9.0`, ``}}}
+)
+
+const stagesPath = `/api/stages`
+
+// Identifies a single run of a stage
+type StageLocator struct {
+	Pipeline        string
+	PipelineCounter int
+	Stage           string
+	StageCounter    int
+}
+
+func (l *StageLocator) String() string {
+	return strings.Join(l.segments(), `/`)
+}
+
+func (l *StageLocator) segments() []string {
+	return []string{l.Pipeline, strconv.Itoa(l.PipelineCounter), l.Stage, strconv.Itoa(l.StageCounter)}
+}
+
+// A run counter; GoCD reports these as numbers in some APIs, and as strings
+// in others
+type Counter int
+
+func (c *Counter) UnmarshalJSON(b []byte) error {
+	var n json.Number
+
+	if err := json.Unmarshal(b, &n); err != nil {
+		return err
+	}
+
+	if "" == n {
+		*c = 0
+		return nil
+	}
+
+	i, err := strconv.Atoi(string(n))
+	*c = Counter(i)
+	return err
+}
+
+// A run of a stage, as reported by the stage instance and pipeline history
+// endpoints
+type StageResult struct {
+	Name            string       `json:"name"`
+	Counter         Counter      `json:"counter"`
+	Result          string       `json:"result"`
+	Status          string       `json:"status,omitempty"`
+	ApprovalType    string       `json:"approval_type"`
+	ApprovedBy      string       `json:"approved_by"`
+	Scheduled       bool         `json:"scheduled"`
+	CanRun          bool         `json:"can_run"`
+	RerunOfCounter  *Counter     `json:"rerun_of_counter,omitempty"`
+	PipelineName    string       `json:"pipeline_name,omitempty"`
+	PipelineCounter Counter      `json:"pipeline_counter,omitempty"`
+	Jobs            []*JobResult `json:"jobs"`
+}
+
+// Reports whether the stage has finished, successfully or not
+func (s *StageResult) IsCompleted() bool {
+	switch s.Result {
+	case `Passed`, `Failed`, `Cancelled`:
+		return true
+	}
+	return false
+}
+
+type StagesService struct {
+	c *Client
+}
+
+func (s *StagesService) Instance(ctx context.Context, loc *StageLocator) (*StageResult, error) {
+	result := &StageResult{}
+	_, err := s.c.do(ctx, StagesApi, http.MethodGet, apiPath(stagesPath, loc.segments()...), nil, result)
+	return result, err
+}
+
+func (s *StagesService) Cancel(ctx context.Context, loc *StageLocator) (string, error) {
+	result := &ApiMessage{}
+	_, err := s.c.do(ctx, StagesApi, http.MethodPost, apiPath(stagesPath, append(loc.segments(), `cancel`)...), nil, result)
+	return result.String(), err
+}
+
+// Runs (or reruns) a stage of an existing pipeline run, e.g., to approve a
+// manual stage
+func (s *StagesService) Run(ctx context.Context, pipeline string, pipelineCounter int, stage string) (string, error) {
+	result := &ApiMessage{}
+	_, err := s.c.do(ctx, StageRunApi, http.MethodPost, apiPath(stagesPath, pipeline, strconv.Itoa(pipelineCounter), stage, `run`), nil, result)
+	return result.String(), err
+}
+
+func (s *StagesService) RunFailedJobs(ctx context.Context, loc *StageLocator) (string, error) {
+	result := &ApiMessage{}
+	_, err := s.c.do(ctx, StagesApi, http.MethodPost, apiPath(stagesPath, append(loc.segments(), `run-failed-jobs`)...), nil, result)
+	return result.String(), err
+}
+
+func (s *StagesService) RunSelectedJobs(ctx context.Context, loc *StageLocator, jobs []string) (string, error) {
+	result := &ApiMessage{}
+	_, err := s.c.do(ctx, StagesApi, http.MethodPost, apiPath(stagesPath, append(loc.segments(), `run-selected-jobs`)...), map[string][]string{`jobs`: jobs}, result)
+	return result.String(), err
+}
@@ -77,6 +77,7 @@ func nopRt() mockRT {
 func useRt(status int, body string) mockRT {
 	return mockRT(func(rq *http.Request) (rs *http.Response, e error) {
 		rs = resp(status, body)
+		rs.Request = rq
 		return
 	})
 }
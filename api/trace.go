@@ -2,6 +2,7 @@ package api
 
 import (
 	"io"
+	"net/http"
 
 	"github.com/gocd-contrib/gocd-cli/dub"
 	"github.com/gocd-contrib/gocd-cli/utils"
@@ -13,34 +14,47 @@ func (dw debugWriter) Write(b []byte) (int, error) {
 	return len(b), dw(b)
 }
 
-func traceRequest(r *dub.Request) {
-	utils.Debug(`Sending API request %s %s`, r.Method, r.Url)
+// Couples a TeeReader with the original body's Close()
+type tracedBody struct {
+	io.Reader
+	io.Closer
+}
 
-	if utils.DebugMode {
-		utils.Debug(`Headers >>>`)
-		for h, vals := range r.Headers {
-			if "Authorization" == h {
-				vals = []string{`:: REDACTED ::`}
+// Middleware that logs each request's method, URL, headers, and body (as
+// it is sent) in debug mode; the Authorization header is always redacted
+func TraceRequests(next dub.Handler) dub.Handler {
+	return func(req *http.Request) (*http.Response, error) {
+		utils.Debug(`Sending API request %s %s`, req.Method, req.URL)
+
+		if utils.DebugMode {
+			utils.Debug(`Headers >>>`)
+			for h, vals := range req.Header {
+				if "Authorization" == h {
+					vals = []string{`:: REDACTED ::`}
+				}
+
+				for _, v := range vals {
+					utils.Debug(`%s: %s`, h, v)
+				}
 			}
 
-			for _, v := range vals {
-				utils.Debug(`%s: %s`, h, v)
+			if req.Body != nil && req.Body != http.NoBody {
+				bodyTap := debugWriter(func(b []byte) error {
+					utils.Debug(string(b))
+					return nil
+				})
+
+				utils.Debug(`Body >>>`)
+				req.Body = &tracedBody{Reader: io.TeeReader(req.Body, bodyTap), Closer: req.Body}
 			}
 		}
 
-		if r.Body != nil {
-			bodyTap := debugWriter(func(b []byte) error {
-				utils.Debug(string(b))
-				return nil
-			})
-
-			utils.Debug(`Body >>>`)
+		res, err := next(req)
 
-			if mult, ok := r.Body.(*dub.Multipart); ok {
-				mult.MultipartPayload = dub.NewWireTapPayload(mult.MultipartPayload, bodyTap)
-			} else {
-				r.Body = io.TeeReader(r.Body, bodyTap)
-			}
+		if err == nil {
+			utils.Debug(`Received %d response for %s %s`, res.StatusCode, req.Method, req.URL)
 		}
+
+		return res, err
 	}
 }
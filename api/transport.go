@@ -0,0 +1,119 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/gocd-contrib/gocd-cli/cfg"
+	"github.com/gocd-contrib/gocd-cli/dub"
+	"github.com/gocd-contrib/gocd-cli/utils"
+)
+
+// Builds a dub.Client for making requests to the GoCD server of the active
+// profile, honoring its TLS settings and the proxy settings, and tracing
+// requests in debug mode
+func NewHttpClient(conf *cfg.Config) (*dub.Client, error) {
+	t := proxiedTransport(conf)
+
+	if tc, err := TLSConfig(conf.GetTLS()); err != nil {
+		return nil, err
+	} else {
+		t.TLSClientConfig = tc
+	}
+
+	return dub.Make(t).Retry(RetryPolicy(conf)).Use(TraceRequests), nil
+}
+
+// Builds a dub.Client for downloads from anywhere other than the GoCD server
+// (e.g., plugin releases from GitHub), honoring only the proxy settings
+func NewDownloadClient(conf *cfg.Config) *dub.Client {
+	return dub.Make(proxiedTransport(conf)).Retry(RetryPolicy(conf))
+}
+
+// Translates the configured retry settings to a dub.RetryPolicy that logs
+// each retry in debug mode
+func RetryPolicy(conf *cfg.Config) *dub.RetryPolicy {
+	r := conf.GetRetry()
+
+	return &dub.RetryPolicy{
+		MaxAttempts: r.MaxAttempts,
+		BaseDelay:   r.BaseDelay,
+		Jitter:      r.Jitter,
+		OnRetry: func(rt *dub.Retry) {
+			reason := rt.Err
+
+			if nil != rt.Response {
+				reason = fmt.Errorf(`status %d`, rt.Response.StatusCode)
+			}
+
+			if rt.Attempt >= rt.MaxAttempts {
+				utils.Debug(`Not retrying %s %s: %v`, rt.Request.Method, rt.Request.Url, reason)
+			} else {
+				utils.Debug(`Attempt %d of %d for %s %s failed (%v); retrying in %v`, rt.Attempt, rt.MaxAttempts, rt.Request.Method, rt.Request.Url, reason, rt.Delay)
+			}
+		},
+	}
+}
+
+func proxiedTransport(conf *cfg.Config) *http.Transport {
+	p := conf.GetProxy()
+	t := dub.NewTransport()
+	t.Proxy = (&dub.ProxyConfig{HTTPProxy: p.HTTP, HTTPSProxy: p.HTTPS, NoProxy: p.NoProxy}).ProxyFunc()
+	return t
+}
+
+// Translates the configured TLS settings to a *tls.Config; returns nil when
+// there is nothing to customize so the transport keeps its defaults
+func TLSConfig(settings *cfg.TLSSettings) (*tls.Config, error) {
+	if *settings == (cfg.TLSSettings{}) {
+		return nil, nil
+	}
+
+	tc := &tls.Config{}
+
+	if "" != settings.CAFile {
+		pool, err := x509.SystemCertPool()
+
+		if err != nil {
+			utils.InspectError(err, `loading system cert pool; will only trust %q`, settings.CAFile)
+			pool = x509.NewCertPool()
+		}
+
+		pem, err := os.ReadFile(settings.CAFile)
+
+		if err != nil {
+			return nil, utils.InspectError(err, `reading tls ca_file %q`, settings.CAFile)
+		}
+
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf(`No PEM-encoded certificates found in tls ca_file %q`, settings.CAFile)
+		}
+
+		tc.RootCAs = pool
+	}
+
+	if "" != settings.ClientCert || "" != settings.ClientKey {
+		if "" == settings.ClientCert || "" == settings.ClientKey {
+			return nil, errors.New(`Both tls client_cert and client_key must be configured to use a client certificate`)
+		}
+
+		cert, err := tls.LoadX509KeyPair(settings.ClientCert, settings.ClientKey)
+
+		if err != nil {
+			return nil, utils.InspectError(err, `loading tls client certificate %q and key %q`, settings.ClientCert, settings.ClientKey)
+		}
+
+		tc.Certificates = []tls.Certificate{cert}
+	}
+
+	if settings.InsecureSkipVerify {
+		utils.Debug(`TLS certificate verification is DISABLED`)
+		tc.InsecureSkipVerify = true
+	}
+
+	return tc, nil
+}
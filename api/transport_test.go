@@ -0,0 +1,173 @@
+package api_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/cfg"
+	"github.com/gocd-contrib/gocd-cli/dub"
+	"github.com/spf13/afero"
+)
+
+func TestTLSConfigIsNilWithoutSettings(t *testing.T) {
+	as := asserts(t)
+
+	tc, err := api.TLSConfig(&cfg.TLSSettings{})
+	as.ok(err)
+	as.is(nil == tc)
+}
+
+func TestTLSConfigLoadsClientCertificate(t *testing.T) {
+	as := asserts(t)
+	dir := t.TempDir()
+	cert, key := writeKeyPair(t, dir)
+
+	tc, err := api.TLSConfig(&cfg.TLSSettings{ClientCert: cert, ClientKey: key})
+	as.ok(err)
+	as.eq(1, len(tc.Certificates))
+
+	_, err = api.TLSConfig(&cfg.TLSSettings{ClientCert: cert})
+	as.err(`Both tls client_cert and client_key must be configured to use a client certificate`, err)
+}
+
+func TestTLSConfigRejectsInvalidCAFile(t *testing.T) {
+	as := asserts(t)
+	path := filepath.Join(t.TempDir(), `ca.pem`)
+	as.ok(os.WriteFile(path, []byte(`not a cert`), 0644))
+
+	_, err := api.TLSConfig(&cfg.TLSSettings{CAFile: path})
+	as.err(`No PEM-encoded certificates found in tls ca_file "`+path+`"`, err)
+}
+
+func TestBuilderTrustsConfiguredCA(t *testing.T) {
+	as := asserts(t)
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	conf := tlsTestConf(t, srv.URL+`/go`)
+
+	// untrusted by default
+	err := api.New(1, conf, nil).Get(`/api/test`).Send(context.Background(), nil, nil)
+	as.is(nil != err)
+
+	caFile := filepath.Join(t.TempDir(), `ca.pem`)
+	as.ok(os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: `CERTIFICATE`, Bytes: srv.Certificate().Raw}), 0644))
+	as.ok(conf.SetTLSCAFile(caFile))
+
+	didRun := false
+	as.ok(api.New(1, conf, nil).Get(`/api/test`).Send(context.Background(), func(res *dub.Response) error {
+		didRun = true
+		return nil
+	}, nil))
+	as.is(didRun)
+}
+
+func TestBuilderCanSkipVerification(t *testing.T) {
+	as := asserts(t)
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	conf := tlsTestConf(t, srv.URL+`/go`)
+	as.ok(conf.SetTLSInsecureSkipVerify(true))
+
+	as.ok(api.New(1, conf, nil).Get(`/api/test`).Send(context.Background(), nil, nil))
+}
+
+func TestBuilderReportsTLSConfigErrors(t *testing.T) {
+	as := asserts(t)
+
+	conf := tlsTestConf(t, `https://test/go`)
+	caFile := filepath.Join(t.TempDir(), `ca.pem`)
+	as.ok(os.WriteFile(caFile, []byte(`not a cert`), 0644))
+	as.ok(conf.SetTLSCAFile(caFile))
+
+	err := api.New(1, conf, nil).Get(`/api/test`).Send(context.Background(), nil, nil)
+	as.err(`No PEM-encoded certificates found in tls ca_file "`+caFile+`"`, err)
+}
+
+func TestBuilderSendsRequestsThroughProxy(t *testing.T) {
+	as := asserts(t)
+
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.Write([]byte(`{}`))
+	}))
+	defer proxy.Close()
+
+	conf := tlsTestConf(t, `http://gocd.test:8153/go`)
+	as.ok(conf.SetProxy(`http`, proxy.URL))
+	as.ok(conf.OverrideRetries(0))
+
+	as.ok(api.New(1, conf, nil).Get(`/api/test`).Send(context.Background(), nil, nil))
+	as.eq(`http://gocd.test:8153/go/api/test`, proxied)
+
+	proxied = ``
+	as.ok(conf.SetNoProxy(`gocd.test`))
+	err := api.New(1, conf, nil).Get(`/api/test`).Send(context.Background(), nil, nil)
+	as.is(nil != err)
+	as.eq(``, proxied)
+}
+
+// a config backed by the OS filesystem so TLS files can be validated
+func tlsTestConf(t *testing.T, serverUrl string) *cfg.Config {
+	t.Helper()
+	as := asserts(t)
+
+	path := filepath.Join(t.TempDir(), `settings.yaml`)
+	as.ok(os.WriteFile(path, []byte("config_version: 2\n"), 0644))
+
+	c := cfg.NewConfig(afero.NewOsFs())
+	as.ok(c.Consume(path))
+	as.ok(c.SetServerUrl(serverUrl))
+	as.ok(c.SetRequestsAreUnauthenticated())
+	return c
+}
+
+func writeKeyPair(t *testing.T, dir string) (certFile, keyFile string) {
+	t.Helper()
+	as := asserts(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	as.ok(err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: `gocd-cli-test`},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	as.ok(err)
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	as.ok(err)
+
+	certFile = filepath.Join(dir, `client.crt`)
+	keyFile = filepath.Join(dir, `client.key`)
+
+	as.ok(os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: `CERTIFICATE`, Bytes: der}), 0644))
+	as.ok(os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: `EC PRIVATE KEY`, Bytes: keyDer}), 0600))
+	return
+}
@@ -0,0 +1,187 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/blang/semver"
+	"github.com/gocd-contrib/gocd-cli/utils"
+)
+
+// An API version served by a range of GoCD releases
+type ApiVersion struct {
+	Version int
+
+	// The first GoCD release serving this version
+	Since string
+
+	// The first GoCD release that no longer serves this version; empty if
+	// current releases still do
+	Until string
+}
+
+func (av ApiVersion) servedBy(server semver.Version) bool {
+	if server.LT(semver.MustParse(av.Since)) {
+		return false
+	}
+	return "" == av.Until || server.LT(semver.MustParse(av.Until))
+}
+
+// An API endpoint (or a group of endpoints versioned together), declaring
+// the API versions it supports
+type Endpoint struct {
+	Name string
+
+	// Supported versions, most preferred first
+	Versions []ApiVersion
+}
+
+// The version to use when the server's version is unknown
+func (e *Endpoint) Preferred() int {
+	return e.Versions[0].Version
+}
+
+// Picks the most preferred version that the given GoCD release serves
+func (e *Endpoint) Pick(serverVersion string) (int, error) {
+	server, err := semver.ParseTolerant(serverVersion)
+
+	if err != nil {
+		utils.Debug(`cannot parse server version %q; assuming v%d of the %s API`, serverVersion, e.Preferred(), e.Name)
+		return e.Preferred(), nil
+	}
+
+	tooOld := true
+
+	for _, av := range e.Versions {
+		if av.servedBy(server) {
+			return av.Version, nil
+		}
+
+		if server.GTE(semver.MustParse(av.Since)) {
+			tooOld = false
+		}
+	}
+
+	return 0, &VersionError{Endpoint: e, Server: serverVersion, TooOld: tooOld}
+}
+
+// Reports that the server does not serve any version of an endpoint that
+// this client knows
+type VersionError struct {
+	Endpoint *Endpoint
+	Server   string
+	TooOld   bool
+}
+
+func (e *VersionError) Error() string {
+	if e.TooOld {
+		oldest := e.Endpoint.Versions[len(e.Endpoint.Versions)-1]
+		return fmt.Sprintf(`GoCD server %s is too old for the %s API; upgrade the server to %s or later`, e.Server, e.Endpoint.Name, oldest.Since)
+	}
+	return fmt.Sprintf(`GoCD server %s is too new for this version of gocd-cli (it no longer serves any version of the %s API that gocd-cli knows); upgrade gocd-cli`, e.Server, e.Endpoint.Name)
+}
+
+var VersionApi = &Endpoint{`version`, []ApiVersion{{1, `16.6.0`, ``}}}
+
+type ServerInfo struct {
+	Version     string `json:"version"`
+	BuildNumber string `json:"build_number"`
+	GitSha      string `json:"git_sha"`
+	FullVersion string `json:"full_version"`
+	CommitUrl   string `json:"commit_url"`
+}
+
+// Remembers the server version (or the server's failure to report it) for
+// the lifetime of a Client
+type versionMemo struct {
+	mu      sync.Mutex
+	version string
+	err     error
+}
+
+// Fetches the server's version and build information
+func (c *Client) ServerInfo(ctx context.Context) (*ServerInfo, error) {
+	result := &ServerInfo{}
+	_, err := c.V(VersionApi.Preferred()).Get(`/api/version`).Decode(ctx, result)
+	return result, err
+}
+
+// Returns the server's version, preferring a recently cached value over
+// asking the server
+func (c *Client) ServerVersion(ctx context.Context) (string, error) {
+	c.memo.mu.Lock()
+	defer c.memo.mu.Unlock()
+
+	if "" != c.memo.version || nil != c.memo.err {
+		return c.memo.version, c.memo.err
+	}
+
+	conf := c.base.conf
+	serverUrl := conf.GetServerUrl()
+
+	if v := conf.CachedServerVersion(serverUrl); "" != v {
+		c.memo.version = v
+		return v, nil
+	}
+
+	info, err := c.ServerInfo(ctx)
+
+	if err != nil {
+		// the server will not tell, e.g., as it predates the version API, so
+		// there is no point in asking again; other failures may be transient
+		if errors.As(err, new(*Error)) {
+			c.memo.err = err
+		}
+		return "", err
+	}
+
+	if "" != info.Version {
+		if err := conf.CacheServerVersion(serverUrl, info.Version); err != nil {
+			utils.Debug(`failed to cache server version: %v`, err)
+		}
+	}
+
+	c.memo.version = info.Version
+	return info.Version, nil
+}
+
+// Discards the remembered and cached server version, so that the next
+// request detects it anew
+func (c *Client) forgetServerVersion() {
+	c.memo.mu.Lock()
+	defer c.memo.mu.Unlock()
+
+	c.memo.version, c.memo.err = "", nil
+
+	if err := c.base.conf.ForgetServerVersion(c.base.conf.GetServerUrl()); err != nil {
+		utils.Debug(`failed to clear cached server version: %v`, err)
+	}
+}
+
+// Returns a Builder for the best version of the endpoint that both this
+// client and the server support
+func (c *Client) For(ctx context.Context, ep *Endpoint) (*Builder, error) {
+	server, err := c.ServerVersion(ctx)
+
+	if err != nil {
+		var apiErr *Error
+
+		if !errors.As(err, &apiErr) {
+			return nil, err
+		}
+
+		// e.g., the server predates the version API; let the request itself
+		// report any problem
+		utils.Debug(`cannot detect server version (%v); assuming v%d of the %s API`, err, ep.Preferred(), ep.Name)
+		return c.V(ep.Preferred()), nil
+	}
+
+	if version, err := ep.Pick(server); err != nil {
+		return nil, err
+	} else {
+		utils.Debug(`using v%d of the %s API for GoCD server %s`, version, ep.Name, server)
+		return c.V(version), nil
+	}
+}
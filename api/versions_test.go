@@ -0,0 +1,196 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/dub"
+)
+
+var testEndpoint = &api.Endpoint{`widgets`, []api.ApiVersion{
+	{3, `21.1.0`, ``},
+	{2, `19.1.0`, `22.1.0`},
+	{1, `18.1.0`, `20.1.0`},
+}}
+
+func TestEndpointPicksMostPreferredServedVersion(t *testing.T) {
+	as := asserts(t)
+
+	v, err := testEndpoint.Pick(`23.4.0`)
+	as.ok(err)
+	as.eq(3, v)
+
+	v, err = testEndpoint.Pick(`21.3.0`)
+	as.ok(err)
+	as.eq(3, v)
+
+	v, err = testEndpoint.Pick(`20.1.0`)
+	as.ok(err)
+	as.eq(2, v)
+
+	v, err = testEndpoint.Pick(`18.6.0`)
+	as.ok(err)
+	as.eq(1, v)
+}
+
+func TestEndpointRejectsServersTooOldOrNew(t *testing.T) {
+	as := asserts(t)
+
+	_, err := testEndpoint.Pick(`17.2.0`)
+	as.err(`GoCD server 17.2.0 is too old for the widgets API; upgrade the server to 18.1.0 or later`, err)
+
+	retired := &api.Endpoint{`widgets`, []api.ApiVersion{{1, `18.1.0`, `20.1.0`}}}
+	_, err = retired.Pick(`20.1.0`)
+	as.err(`GoCD server 20.1.0 is too new for this version of gocd-cli (it no longer serves any version of the widgets API that gocd-cli knows); upgrade gocd-cli`, err)
+}
+
+func TestEndpointAssumesPreferredVersionForUnknownServer(t *testing.T) {
+	as := asserts(t)
+
+	v, err := testEndpoint.Pick(`unknown`)
+	as.ok(err)
+	as.eq(3, v)
+}
+
+// Serves /api/version with the given version (or status, if non-200), and
+// 200 for anything else
+func versionRt(versionRequests *int, status int, version string) mockRT {
+	return mockRT(func(rq *http.Request) (*http.Response, error) {
+		var rs *http.Response
+
+		if strings.HasSuffix(rq.URL.Path, `/api/version`) {
+			*versionRequests++
+
+			if http.StatusOK == status {
+				rs = resp(status, `{"version":"`+version+`"}`)
+			} else {
+				rs = resp(status, `{"message":"nope"}`)
+			}
+		} else {
+			rs = resp(200, `{"message":"`+rq.Header.Get(`Accept`)+`"}`)
+		}
+
+		rs.Request = rq
+		return rs, nil
+	})
+}
+
+func TestClientNegotiatesAndCachesServerVersion(t *testing.T) {
+	as := asserts(t)
+	conf, err := makeConf("server:\n  url: http://test/go\nauth:\n  type: none\n")
+	as.ok(err)
+
+	versionRequests := 0
+	c := api.NewClient(conf, dub.Make(versionRt(&versionRequests, 200, `20.5.0`)))
+
+	b, err := c.For(context.Background(), testEndpoint)
+	as.ok(err)
+	as.eq(2, b.ApiVersion)
+
+	_, err = c.For(context.Background(), testEndpoint)
+	as.ok(err)
+	as.eq(1, versionRequests)
+	as.eq(`20.5.0`, conf.CachedServerVersion(`http://test/go`))
+
+	// another invocation reuses the cached version
+	c = api.NewClient(conf, dub.Make(versionRt(&versionRequests, 200, `99.0.0`)))
+	v, err := c.ServerVersion(context.Background())
+	as.ok(err)
+	as.eq(`20.5.0`, v)
+	as.eq(1, versionRequests)
+}
+
+func TestClientFallsBackToPreferredVersionWhenUndetectable(t *testing.T) {
+	as := asserts(t)
+	conf, err := makeConf("server:\n  url: http://test/go\nauth:\n  type: none\n")
+	as.ok(err)
+
+	versionRequests := 0
+	c := api.NewClient(conf, dub.Make(versionRt(&versionRequests, 404, ``)))
+
+	b, err := c.For(context.Background(), testEndpoint)
+	as.ok(err)
+	as.eq(3, b.ApiVersion)
+	as.eq(``, conf.CachedServerVersion(`http://test/go`))
+}
+
+func TestClientReportsIncompatibleServer(t *testing.T) {
+	as := asserts(t)
+	conf, err := makeConf("server:\n  url: http://test/go\nauth:\n  type: none\n")
+	as.ok(err)
+
+	versionRequests := 0
+	c := api.NewClient(conf, dub.Make(versionRt(&versionRequests, 200, `19.1.0`)))
+
+	_, err = c.Agents.List(context.Background())
+	as.err(`GoCD server 19.1.0 is too old for the agents API; upgrade the server to 20.1.0 or later`, err)
+}
+
+func TestClientRemembersUndetectableServerVersion(t *testing.T) {
+	as := asserts(t)
+	conf, err := makeConf("server:\n  url: http://test/go\nauth:\n  type: none\n")
+	as.ok(err)
+
+	versionRequests := 0
+	c := api.NewClient(conf, dub.Make(versionRt(&versionRequests, 404, ``)))
+
+	_, err = c.For(context.Background(), testEndpoint)
+	as.ok(err)
+	_, err = c.For(context.Background(), testEndpoint)
+	as.ok(err)
+	as.eq(1, versionRequests)
+}
+
+// Serves /api/version with the given version, and config repos only at the
+// given API version, responding 406 to any other
+func upgradedRt(versionRequests *int, version, accept string) mockRT {
+	return mockRT(func(rq *http.Request) (*http.Response, error) {
+		var rs *http.Response
+
+		switch {
+		case strings.HasSuffix(rq.URL.Path, `/api/version`):
+			*versionRequests++
+			rs = resp(200, `{"version":"`+version+`"}`)
+		case accept == rq.Header.Get(`Accept`):
+			rs = resp(200, `{"_embedded":{"config_repos":[{"id":"a"}]}}`)
+		default:
+			rs = resp(406, `{"message":"not acceptable"}`)
+		}
+
+		rs.Request = rq
+		return rs, nil
+	})
+}
+
+func TestClientRedetectsServerVersionWhenVersionIsNotAcceptable(t *testing.T) {
+	as := asserts(t)
+	conf, err := makeConf("server:\n  url: http://test/go\nauth:\n  type: none\n")
+	as.ok(err)
+	as.ok(conf.CacheServerVersion(`http://test/go`, `20.1.0`))
+
+	versionRequests := 0
+	c := api.NewClient(conf, dub.Make(upgradedRt(&versionRequests, `21.1.0`, `application/vnd.go.cd.v4+json`)))
+
+	repos, err := c.ConfigRepos.List(context.Background())
+	as.ok(err)
+	as.eq(1, len(repos))
+	as.eq(1, versionRequests)
+	as.eq(`21.1.0`, conf.CachedServerVersion(`http://test/go`))
+}
+
+func TestClientReportsNotAcceptableVersionWhenRedetectionDoesNotHelp(t *testing.T) {
+	as := asserts(t)
+	conf, err := makeConf("server:\n  url: http://test/go\nauth:\n  type: none\n")
+	as.ok(err)
+	as.ok(conf.CacheServerVersion(`http://test/go`, `21.1.0`))
+
+	versionRequests := 0
+	c := api.NewClient(conf, dub.Make(upgradedRt(&versionRequests, `21.1.0`, `application/vnd.go.cd.v9+json`)))
+
+	_, err = c.ConfigRepos.List(context.Background())
+	as.err(`GoCD server does not serve v4 of the config repos API`, err)
+	as.eq(1, versionRequests)
+}
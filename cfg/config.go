@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/gocd-contrib/gocd-cli/utils"
@@ -21,17 +22,24 @@ const (
 	CONFIG_FILETYPE = `yaml`
 	CONFIG_ENV_PFX  = `gocdcli`
 	CONFIG_VERSION  = `config_version`
-	CURRENT_VERSION = 1 // format version
+	CURRENT_VERSION = 2 // format version
+
+	PROFILES_KEY        = `profiles`
+	CURRENT_PROFILE_KEY = `current_profile`
+	DEFAULT_PROFILE     = `default`
+	PROFILE_ENV_VAR     = `GOCDCLI_PROFILE`
 )
 
 type dict map[string]interface{}
 
 type Config struct {
-	native *viper.Viper
-	fs     afero.Fs
+	native    *viper.Viper
+	fs        afero.Fs
+	overrides map[string]bool
 }
 
 var onlyNumeric, _ = regexp.Compile(`^\d+$`)
+var validProfileName, _ = regexp.Compile(`^[a-z0-9][a-z0-9_-]*$`)
 
 func (c *Config) SetServerUrl(urlArg string) error {
 	if "" == urlArg {
@@ -40,14 +48,17 @@ func (c *Config) SetServerUrl(urlArg string) error {
 
 	return utils.InspectError(
 		c.WithBaseUrlValidation(urlArg, func(u string) error {
-			c.native.Set("server.url", u)
-			return utils.InspectError(c.native.WriteConfig(), `writing server-url to config`)
+			key := c.profileKey(`server.url`)
+			return utils.InspectError(c.writeConfigExcludingKey(key, func(cfg dict) error {
+				cfg[key] = u
+				return nil
+			}), `writing server-url to config`)
 		}), `validating server-url on set() => %q`, urlArg,
 	)
 }
 
 func (c *Config) GetServerUrl() string {
-	return c.native.GetString("server.url")
+	return c.getString(`server.url`)
 }
 
 func (c *Config) WithBaseUrlValidation(urlArg string, onValid func(string) error) error {
@@ -79,8 +90,8 @@ func (c *Config) WithBaseUrlValidation(urlArg string, onValid func(string) error
 }
 
 func (c *Config) SetRequestsAreUnauthenticated() error {
-	return utils.InspectError(c.writeConfigExcludingKey(`auth`, func(cfg dict) error {
-		cfg[`auth`] = dict{
+	return utils.InspectError(c.writeConfigExcludingKey(c.profileKey(`auth`), func(cfg dict) error {
+		cfg[c.profileKey(`auth`)] = dict{
 			`type`: `none`,
 		}
 		return nil
@@ -92,13 +103,17 @@ func (c *Config) SetBasicAuth(user, pass string) error {
 		return errors.New("Must specify user and password")
 	}
 
-	return utils.InspectError(c.writeConfigExcludingKey(`auth`, func(cfg dict) error {
-		cfg[`auth`] = dict{
-			`type`:     `basic`,
-			`user`:     user,
-			`password`: pass,
+	return utils.InspectError(c.writeConfigExcludingKey(c.profileKey(`auth`), func(cfg dict) error {
+		if sealed, err := c.sealIfEnabled(pass); err == nil {
+			cfg[c.profileKey(`auth`)] = dict{
+				`type`:     `basic`,
+				`user`:     user,
+				`password`: sealed,
+			}
+			return nil
+		} else {
+			return err
 		}
-		return nil
 	}), `writing basic auth credentials to config`)
 }
 
@@ -107,17 +122,37 @@ func (c *Config) SetTokenAuth(token string) error {
 		return errors.New("Must specify bearer token")
 	}
 
-	return utils.InspectError(c.writeConfigExcludingKey(`auth`, func(cfg dict) error {
-		cfg[`auth`] = dict{
-			`type`:  `token`,
-			`token`: token,
+	return utils.InspectError(c.writeConfigExcludingKey(c.profileKey(`auth`), func(cfg dict) error {
+		if sealed, err := c.sealIfEnabled(token); err == nil {
+			cfg[c.profileKey(`auth`)] = dict{
+				`type`:  `token`,
+				`token`: sealed,
+			}
+			return nil
+		} else {
+			return err
 		}
-		return nil
 	}), `writing auth token to config`)
 }
 
+// Configures an external command that prints an auth token to STDOUT, much
+// like git's credential helpers; this keeps secrets out of the config file
+func (c *Config) SetHelperAuth(command string) error {
+	if "" == strings.TrimSpace(command) {
+		return errors.New("Must specify auth helper command")
+	}
+
+	return utils.InspectError(c.writeConfigExcludingKey(c.profileKey(`auth`), func(cfg dict) error {
+		cfg[c.profileKey(`auth`)] = dict{
+			`type`:    `helper`,
+			`command`: command,
+		}
+		return nil
+	}), `writing auth helper command to config`)
+}
+
 func (c *Config) GetAuth() map[string]string {
-	authType := c.native.GetString(`auth.type`)
+	authType := c.getString(`auth.type`)
 
 	result := map[string]string{
 		`type`: authType,
@@ -128,23 +163,186 @@ func (c *Config) GetAuth() map[string]string {
 		// favor accessing individual nested keys so we honor
 		// environment variable overrides with Viper (i.e.,
 		// GOCDCLI_AUTH.* environment variables)
-		setIfPresent(result, `auth.user`, `user`, c.native)
-		setIfPresent(result, `auth.password`, `password`, c.native)
+		c.setIfPresent(result, `auth.user`, `user`)
+		c.setSecretIfPresent(result, `auth.password`, `password`)
 		return result
 	case `token`:
-		setIfPresent(result, `auth.token`, `token`, c.native)
+		c.setSecretIfPresent(result, `auth.token`, `token`)
+		return result
+	case `helper`:
+		c.setIfPresent(result, `auth.command`, `command`)
 		return result
 	case `none`:
 		return result
 	default:
-		return c.native.GetStringMapString(`auth`)
+		if auth := c.native.GetStringMapString(`auth`); len(auth) > 0 {
+			return auth
+		}
+		return c.native.GetStringMapString(c.profileKey(`auth`))
+	}
+}
+
+// Returns the name of the profile in effect for this invocation. In order of
+// precedence, this is determined by `--profile`, the GOCDCLI_PROFILE environment
+// variable, and finally the `current_profile` key in the config file.
+func (c *Config) ActiveProfile() string {
+	if name := c.native.GetString(CURRENT_PROFILE_KEY); `` != name {
+		return name
+	}
+	return DEFAULT_PROFILE
+}
+
+// Overrides the active profile for the current invocation only; nothing is
+// written to disk
+func (c *Config) SelectProfile(name string) error {
+	if err := validateProfileName(name); err != nil {
+		return err
+	}
+
+	c.native.Set(CURRENT_PROFILE_KEY, name)
+	c.overrides[CURRENT_PROFILE_KEY] = true
+	return nil
+}
+
+// Checks a profile selected through GOCDCLI_PROFILE as SelectProfile() checks
+// one selected with --profile, and that it exists, as an unknown name would
+// otherwise resolve to an empty profile
+func (c *Config) ValidateProfileEnv() error {
+	name := os.Getenv(PROFILE_ENV_VAR)
+
+	if `` == name || c.overrides[CURRENT_PROFILE_KEY] {
+		return nil
+	}
+
+	if err := validateProfileName(name); err != nil {
+		return fmt.Errorf(`%v (from %s)`, err, PROFILE_ENV_VAR)
 	}
+
+	if DEFAULT_PROFILE != name && !c.HasProfile(name) {
+		return fmt.Errorf(`No such profile %q (from %s); known profiles: %v`, name, PROFILE_ENV_VAR, c.Profiles())
+	}
+
+	return nil
+}
+
+// Lists the profiles declared in the config file. Reads from disk because
+// clearing a nested key in Viper's override register shadows its siblings
+// when fetching the parent map.
+func (c *Config) Profiles() []string {
+	names := make([]string, 0)
+
+	if ro, err := c.fsOnlyViper(c.fs); err == nil {
+		for name := range ro.GetStringMap(PROFILES_KEY) {
+			names = append(names, name)
+		}
+	} else {
+		utils.InspectError(err, `reading profiles from config file`)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+func (c *Config) HasProfile(name string) bool {
+	for _, p := range c.Profiles() {
+		if p == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Config) AddProfile(name, urlArg string) error {
+	if err := validateProfileName(name); err != nil {
+		return err
+	}
+
+	if c.HasProfile(name) {
+		return fmt.Errorf(`Profile %q already exists`, name)
+	}
+
+	return utils.InspectError(
+		c.WithBaseUrlValidation(urlArg, func(u string) error {
+			key := strings.Join([]string{PROFILES_KEY, name, `server.url`}, `.`)
+			return c.writeConfigExcludingKey(key, func(cfg dict) error {
+				cfg[key] = u
+				return nil
+			})
+		}), `adding profile %q`, name,
+	)
+}
+
+// Persists the default profile to the config file
+func (c *Config) UseProfile(name string) error {
+	if !c.HasProfile(name) {
+		return fmt.Errorf(`No such profile %q; known profiles: %v`, name, c.Profiles())
+	}
+
+	return utils.InspectError(c.writeConfigExcludingKey(CURRENT_PROFILE_KEY, func(cfg dict) error {
+		cfg[CURRENT_PROFILE_KEY] = name
+		return nil
+	}), `setting current profile to %q`, name)
+}
+
+// Removes a profile, unless it is in use; removing the active profile would
+// leave every later command pointing at a profile that does not exist
+func (c *Config) RemoveProfile(name string) error {
+	if !c.HasProfile(name) {
+		return fmt.Errorf(`No such profile %q; known profiles: %v`, name, c.Profiles())
+	}
+
+	inUse := fmt.Errorf(`Cannot remove profile %q while it is in use; switch to another profile first with: gocd config profile use <name>`, name)
+
+	if name == c.ActiveProfile() || name == c.persistedProfile() {
+		return inUse
+	}
+
+	return utils.InspectError(c.writeConfigExcludingKey(PROFILES_KEY+`.`+name, nil), `removing profile %q`, name)
+}
+
+// The current profile in the config file, regardless of any selected for this
+// invocation
+func (c *Config) persistedProfile() string {
+	ro, err := c.fsOnlyViper(c.fs)
+
+	if err != nil {
+		utils.InspectError(err, `reading current profile from config file`)
+		return ``
+	}
+
+	if name := ro.GetString(CURRENT_PROFILE_KEY); `` != name {
+		return name
+	}
+	return DEFAULT_PROFILE
+}
+
+func validateProfileName(name string) error {
+	if !validProfileName.MatchString(name) {
+		return fmt.Errorf(`Invalid profile name %q; profile names may only contain lowercase letters, digits, "-", and "_"`, name)
+	}
+	return nil
 }
 
 func (c *Config) ConfigFile() string {
 	return c.native.ConfigFileUsed()
 }
 
+// The directory of the config file in use, which also holds the files that
+// belong with it, so that an alternate config (i.e., --config) has its own
+func (c *Config) configDir() (string, error) {
+	if f := c.ConfigFile(); "" != f {
+		return filepath.Dir(f), nil
+	}
+
+	home, err := homedir.Dir()
+
+	if err != nil {
+		return "", utils.InspectError(err, `resolving user's home directory`)
+	}
+
+	return filepath.Join(home, CONFIG_DIRNAME), nil
+}
+
 func (c *Config) Bootstrap(configFile string, migrations []*migration) error {
 	if err := c.Consume(configFile); err == nil {
 		if err = c.Migrate(migrations); err == nil {
@@ -187,7 +385,12 @@ func (c *Config) Consume(configFile string) error {
 		switch err.(type) {
 		case viper.ConfigFileNotFoundError:
 			utils.Debug(`No config file found; creating a new one at: %q`, configFile)
-			return utils.InspectError(c.native.WriteConfigAs(configFile), `creating a new config file at: %q`, configFile)
+			if err := c.native.WriteConfigAs(configFile); err != nil {
+				return utils.InspectError(err, `creating a new config file at: %q`, configFile)
+			}
+
+			c.native.SetConfigFile(configFile)
+			return nil
 		default:
 			return utils.InspectError(err, `reading config file at default location %q`, configFile)
 		}
@@ -228,6 +431,10 @@ func (c *Config) Migrate(migrations []*migration) error {
 				if err = migrated.WriteConfig(); err != nil {
 					return utils.InspectError(err, `writing back migrated configurations`)
 				}
+
+				if err = c.native.ReadInConfig(); err != nil {
+					return utils.InspectError(err, `reloading migrated config file`)
+				}
 			} else {
 				return utils.InspectError(err, `applying migrations`)
 			}
@@ -243,12 +450,18 @@ func (c *Config) Unset(key string) error {
 	}
 
 	switch key {
-	case `auth-basic`: // we only support a single user profile, so make this an alias
+	case `auth-basic`: // we only support a single set of credentials per profile, so make this an alias
 		fallthrough
 	case `auth`:
-		return c.writeConfigExcludingKey(`auth`, nil)
+		return c.writeConfigExcludingKey(c.profileKey(`auth`), nil)
 	case `server-url`:
-		return c.writeConfigExcludingKey(`server.url`, nil)
+		return c.writeConfigExcludingKey(c.profileKey(`server.url`), nil)
+	case `tls`:
+		return c.writeConfigExcludingKey(c.profileKey(TLS_KEY), nil)
+	case `proxy`:
+		return c.writeConfigExcludingKey(PROXY_KEY, nil)
+	case `retry`:
+		return c.writeConfigExcludingKey(RETRY_KEY, nil)
 	default:
 		return fmt.Errorf(`Unknown key %q`, key)
 	}
@@ -313,7 +526,9 @@ func (c *Config) writeConfigExcludingKey(key string, tap func(dict) error) error
 	}
 
 	if tap != nil {
-		tap(cfg)
+		if err := tap(cfg); err != nil {
+			return err
+		}
 	}
 
 	// `_tmp` is a viper instance that is only for writing to disk.
@@ -356,12 +571,59 @@ func (c *Config) fsOnlyViper(fs afero.Fs) (*viper.Viper, error) {
 	return v, nil
 }
 
-func setIfPresent(result map[string]string, srcKey, destKey string, v *viper.Viper) {
-	if val := v.GetString(srcKey); `` != val {
+// Returns the fully qualified key of a setting scoped to the active profile
+func (c *Config) profileKey(key string) string {
+	return strings.Join([]string{PROFILES_KEY, c.ActiveProfile(), key}, `.`)
+}
+
+// Reads a profile-scoped setting. The unscoped key takes precedence so that
+// environment variable overrides (e.g., GOCDCLI_SERVER.URL) apply to
+// whichever profile is active.
+func (c *Config) getString(key string) string {
+	if val := c.native.GetString(key); `` != val {
+		return val
+	}
+	return c.native.GetString(c.profileKey(key))
+}
+
+// Like getString(), but for boolean settings
+func (c *Config) getBool(key string) bool {
+	if c.native.IsSet(key) {
+		return c.native.GetBool(key)
+	}
+	return c.native.GetBool(c.profileKey(key))
+}
+
+// Writes a single setting, scoped to the active profile, to the config file
+func (c *Config) setProfileValue(key string, value interface{}) error {
+	return c.setValue(c.profileKey(key), value)
+}
+
+// Writes a single setting to the config file
+func (c *Config) setValue(key string, value interface{}) error {
+	return c.writeConfigExcludingKey(key, func(cfg dict) error {
+		cfg[key] = value
+		return nil
+	})
+}
+
+func (c *Config) setIfPresent(result map[string]string, srcKey, destKey string) {
+	if val := c.getString(srcKey); `` != val {
 		result[destKey] = val
 	}
 }
 
+// Like setIfPresent(), but transparently decrypts values encrypted at rest
+func (c *Config) setSecretIfPresent(result map[string]string, srcKey, destKey string) {
+	if val := c.getString(srcKey); `` != val {
+		if plain, err := c.decrypt(val); err == nil {
+			result[destKey] = plain
+		} else {
+			utils.Errfln(`[WARNING] Unable to decrypt %q: %v`, srcKey, err)
+		}
+	}
+}
+
 /**
  * Viper currently does not have a way to test if nested keys are
  * present in the config file. Viper.IsSet() tests against config
@@ -407,8 +669,9 @@ func (c *Config) LayerConfigs() {
 	v.SetDefault(CONFIG_VERSION, CURRENT_VERSION)
 	v.SetEnvPrefix(CONFIG_ENV_PFX)
 	v.AutomaticEnv() // read in environment variables that match
+	v.BindEnv(CURRENT_PROFILE_KEY, PROFILE_ENV_VAR)
 }
 
 func NewConfig(fs afero.Fs) *Config {
-	return &Config{native: newViper(fs), fs: fs}
+	return &Config{native: newViper(fs), fs: fs, overrides: make(map[string]bool)}
 }
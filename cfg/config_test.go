@@ -37,13 +37,13 @@ func TestSetBasicAuth(t *testing.T) {
 
 	as.ok(c.SetBasicAuth(TEST_USER, TEST_PASSWORD))
 
-	as.configEq(dict{
+	as.configEq(inProfile(DEFAULT_PROFILE, dict{
 		"auth": map[string]string{
 			"type":     "basic",
 			"user":     TEST_USER,
 			"password": TEST_PASSWORD,
 		},
-	}, c.fs)
+	}), c.fs)
 }
 
 func TestGetTokenAuth(t *testing.T) {
@@ -68,12 +68,12 @@ func TestSetTokenAuth(t *testing.T) {
 
 	as.ok(c.SetTokenAuth(`gah!`))
 
-	as.configEq(dict{
+	as.configEq(inProfile(DEFAULT_PROFILE, dict{
 		"auth": map[string]string{
 			"type":  "token",
 			"token": "gah!",
 		},
-	}, c.fs)
+	}), c.fs)
 }
 
 func TestSettingAuthClearsPreviousSetting(t *testing.T) {
@@ -87,13 +87,13 @@ func TestSettingAuthClearsPreviousSetting(t *testing.T) {
 		"password": TEST_PASSWORD,
 	}, c.GetAuth())
 
-	as.configEq(dict{
+	as.configEq(inProfile(DEFAULT_PROFILE, dict{
 		"auth": map[string]string{
 			"type":     "basic",
 			"user":     TEST_USER,
 			"password": TEST_PASSWORD,
 		},
-	}, c.fs)
+	}), c.fs)
 
 	as.ok(c.SetTokenAuth(`gah!`))
 
@@ -102,12 +102,12 @@ func TestSettingAuthClearsPreviousSetting(t *testing.T) {
 		"token": "gah!",
 	}, c.GetAuth())
 
-	as.configEq(dict{
+	as.configEq(inProfile(DEFAULT_PROFILE, dict{
 		"auth": map[string]string{
 			"type":  "token",
 			"token": "gah!",
 		},
-	}, c.fs)
+	}), c.fs)
 
 	c.native.ReadInConfig() // ensure we refresh from disk to exclude override register
 
@@ -116,14 +116,14 @@ func TestSettingAuthClearsPreviousSetting(t *testing.T) {
 		"token": "gah!",
 	}, c.GetAuth())
 
-	as.configEq(dict{
+	as.configEq(inProfile(DEFAULT_PROFILE, dict{
 		"auth": map[string]string{
 			"type":  "token",
 			"token": "gah!",
 		},
-	}, c.fs)
+	}), c.fs)
 
-	as.eq(nil, c.native.Get("auth.password"))
+	as.eq(nil, c.native.Get("profiles.default.auth.password"))
 }
 
 func TestSetBasicAuthShouldValidatePresenceOfUserAndPassword(t *testing.T) {
@@ -144,11 +144,11 @@ func TestSetServerURL(t *testing.T) {
 
 	as.ok(c.SetServerUrl(TEST_URL))
 
-	as.configEq(dict{
+	as.configEq(inProfile(DEFAULT_PROFILE, dict{
 		"server": map[string]string{
 			"url": TEST_URL,
 		},
-	}, c.fs)
+	}), c.fs)
 }
 
 func TestGetServerURL(t *testing.T) {
@@ -255,13 +255,15 @@ func TestConsumeLoadsSpecifiedConfigFileWhenExists(t *testing.T) {
 func TestUnsetRemovesConfiguredValue(t *testing.T) {
 	as := asserts(t)
 	c, err := makeConf(`---
-config_version: 1
-auth:
-  type: basic
-  user: admin
-  password: badger
-server:
-  url: http://test/foo
+config_version: 2
+profiles:
+  default:
+    auth:
+      type: basic
+      user: admin
+      password: badger
+    server:
+      url: http://test/foo
 `)
 	as.ok(err)
 	as.eq(`http://test/foo`, c.GetServerUrl())
@@ -278,11 +280,15 @@ server:
 
 	// check disk contents for expected structure
 	as.configEq(dict{
-		`config_version`: 1,
-		`auth`: dict{
-			`type`:     `basic`,
-			`user`:     `admin`,
-			`password`: `badger`,
+		`config_version`: 2,
+		`profiles`: dict{
+			`default`: dict{
+				`auth`: dict{
+					`type`:     `basic`,
+					`user`:     `admin`,
+					`password`: `badger`,
+				},
+			},
 		},
 	}, c.fs)
 }
@@ -290,13 +296,15 @@ server:
 func TestUnsetLeavesOverridesOfOtherKeysIntact(t *testing.T) {
 	as := asserts(t)
 	c, err := makeConf(`---
-config_version: 1
-auth:
-  type: basic
-  user: admin
-  password: badger
-server:
-  url: http://test/foo
+config_version: 2
+profiles:
+  default:
+    auth:
+      type: basic
+      user: admin
+      password: badger
+    server:
+      url: http://test/foo
 `)
 	as.ok(err)
 
@@ -311,7 +319,7 @@ server:
 	// yes, currently this cannot happen as we do not expose `Set()` directly,
 	// but that may change, and we should validate that there are no side-effects
 	// on unrelated keys.
-	c.native.Set(`server.url`, `http://test/bar`)
+	c.native.Set(`profiles.default.server.url`, `http://test/bar`)
 
 	as.eq(`http://test/bar`, c.GetServerUrl())
 	as.ok(c.Unset(`auth`))
@@ -324,9 +332,13 @@ server:
 	// override value if explicitly flush that key to disk, but not as a side-effect
 	// of writing or clearing another key.
 	as.configEq(dict{
-		`config_version`: 1,
-		`server`: dict{
-			`url`: `http://test/foo`,
+		`config_version`: 2,
+		`profiles`: dict{
+			`default`: dict{
+				`server`: dict{
+					`url`: `http://test/foo`,
+				},
+			},
 		},
 	}, c.fs)
 }
@@ -355,3 +367,259 @@ func TestEnvVariableConfig(t *testing.T) {
 		`password`: `007`,
 	}, c.GetAuth())
 }
+
+func TestAddProfile(t *testing.T) {
+	as := asserts(t)
+	c := testConf(true)
+
+	as.ok(c.SetServerUrl(TEST_URL))
+	as.ok(c.AddProfile(`staging`, `http://staging:8153/go`))
+
+	as.configEq(dict{
+		PROFILES_KEY: dict{
+			DEFAULT_PROFILE: dict{
+				`server`: dict{`url`: TEST_URL},
+			},
+			`staging`: dict{
+				`server`: dict{`url`: `http://staging:8153/go`},
+			},
+		},
+	}, c.fs)
+
+	as.deepEq([]string{DEFAULT_PROFILE, `staging`}, c.Profiles())
+
+	// does not change the active profile
+	as.eq(DEFAULT_PROFILE, c.ActiveProfile())
+	as.eq(TEST_URL, c.GetServerUrl())
+}
+
+func TestAddProfileValidatesInput(t *testing.T) {
+	as := asserts(t)
+	c := testConf(true)
+
+	as.ok(c.AddProfile(`staging`, `http://staging:8153/go`))
+	as.err(`Profile "staging" already exists`, c.AddProfile(`staging`, `http://other/go`))
+	as.err(`Invalid profile name "Bad.Name"; profile names may only contain lowercase letters, digits, "-", and "_"`, c.AddProfile(`Bad.Name`, TEST_URL))
+	as.err(`server-url must end with /go`, c.AddProfile(`prod`, `http://prod/bar`))
+	as.not(c.HasProfile(`prod`))
+}
+
+func TestUseProfile(t *testing.T) {
+	as := asserts(t)
+	c, err := makeConf(`---
+config_version: 2
+profiles:
+  default:
+    server:
+      url: http://dev/go
+  prod:
+    server:
+      url: http://prod/go
+    auth:
+      type: token
+      token: prod-token
+`)
+	as.ok(err)
+
+	as.eq(`http://dev/go`, c.GetServerUrl())
+	as.eq(0, len(c.GetAuth()))
+
+	as.ok(c.UseProfile(`prod`))
+	as.eq(`prod`, c.ActiveProfile())
+	as.eq(`http://prod/go`, c.GetServerUrl())
+	as.deepEq(map[string]string{
+		`type`:  `token`,
+		`token`: `prod-token`,
+	}, c.GetAuth())
+
+	// writes to the active profile
+	as.ok(c.SetServerUrl(`http://prod2/go`))
+	as.eq(`http://prod2/go`, c.native.GetString(`profiles.prod.server.url`))
+	as.eq(`http://dev/go`, c.native.GetString(`profiles.default.server.url`))
+
+	as.err(`No such profile "nope"; known profiles: [default prod]`, c.UseProfile(`nope`))
+}
+
+func TestSelectProfileDoesNotWriteToDisk(t *testing.T) {
+	as := asserts(t)
+	c, err := makeConf(`---
+config_version: 2
+profiles:
+  default:
+    server:
+      url: http://dev/go
+  prod:
+    server:
+      url: http://prod/go
+`)
+	as.ok(err)
+
+	as.ok(c.SelectProfile(`prod`))
+	as.eq(`http://prod/go`, c.GetServerUrl())
+
+	as.ok(c.SetBasicAuth(TEST_USER, TEST_PASSWORD))
+
+	as.configEq(dict{
+		CONFIG_VERSION: 2,
+		PROFILES_KEY: dict{
+			DEFAULT_PROFILE: dict{
+				`server`: dict{`url`: `http://dev/go`},
+			},
+			`prod`: dict{
+				`auth`: dict{
+					`password`: TEST_PASSWORD,
+					`type`:     `basic`,
+					`user`:     TEST_USER,
+				},
+				`server`: dict{`url`: `http://prod/go`},
+			},
+		},
+	}, c.fs)
+}
+
+func TestRemoveProfile(t *testing.T) {
+	as := asserts(t)
+	c, err := makeConf(`---
+config_version: 2
+current_profile: prod
+profiles:
+  default:
+    server:
+      url: http://dev/go
+  prod:
+    server:
+      url: http://prod/go
+`)
+	as.ok(err)
+	as.eq(`prod`, c.ActiveProfile())
+
+	as.err(`Cannot remove profile "prod" while it is in use; switch to another profile first with: gocd config profile use <name>`, c.RemoveProfile(`prod`))
+	as.eq(`prod`, c.ActiveProfile())
+
+	as.ok(c.UseProfile(DEFAULT_PROFILE))
+	as.err(`Cannot remove profile "default" while it is in use; switch to another profile first with: gocd config profile use <name>`, c.RemoveProfile(DEFAULT_PROFILE))
+
+	as.ok(c.RemoveProfile(`prod`))
+	as.eq(DEFAULT_PROFILE, c.ActiveProfile())
+	as.eq(`http://dev/go`, c.GetServerUrl())
+
+	as.configEq(dict{
+		CONFIG_VERSION:      2,
+		CURRENT_PROFILE_KEY: DEFAULT_PROFILE,
+		PROFILES_KEY: dict{
+			DEFAULT_PROFILE: dict{
+				`server`: dict{`url`: `http://dev/go`},
+			},
+		},
+	}, c.fs)
+
+	as.err(`No such profile "prod"; known profiles: [default]`, c.RemoveProfile(`prod`))
+}
+
+func TestRemoveProfileRefusesPersistedProfileWhileAnotherIsSelected(t *testing.T) {
+	as := asserts(t)
+	c, err := makeConf(`---
+config_version: 2
+current_profile: prod
+profiles:
+  default:
+    server:
+      url: http://dev/go
+  prod:
+    server:
+      url: http://prod/go
+`)
+	as.ok(err)
+	as.ok(c.SelectProfile(DEFAULT_PROFILE))
+
+	as.err(`Cannot remove profile "prod" while it is in use; switch to another profile first with: gocd config profile use <name>`, c.RemoveProfile(`prod`))
+	as.is(c.HasProfile(`prod`))
+}
+
+func TestProfileEnvVariable(t *testing.T) {
+	os.Clearenv()
+
+	defer os.Clearenv()
+
+	as := asserts(t)
+	c, err := makeConf(`---
+config_version: 2
+profiles:
+  default:
+    server:
+      url: http://dev/go
+  prod:
+    server:
+      url: http://prod/go
+`)
+	as.ok(err)
+	c.LayerConfigs()
+
+	os.Setenv(PROFILE_ENV_VAR, `prod`)
+	as.eq(`prod`, c.ActiveProfile())
+	as.eq(`http://prod/go`, c.GetServerUrl())
+
+	// unscoped environment overrides apply to the active profile
+	os.Setenv(`GOCDCLI_SERVER.URL`, `http://from.env/go`)
+	as.eq(`http://from.env/go`, c.GetServerUrl())
+
+	// explicit selection (i.e., --profile) takes precedence over the environment
+	os.Unsetenv(`GOCDCLI_SERVER.URL`)
+	as.ok(c.SelectProfile(DEFAULT_PROFILE))
+	as.eq(`http://dev/go`, c.GetServerUrl())
+}
+
+func TestValidateProfileEnv(t *testing.T) {
+	os.Clearenv()
+
+	defer os.Clearenv()
+
+	as := asserts(t)
+	c, err := makeConf(`---
+config_version: 2
+profiles:
+  prod:
+    server:
+      url: http://prod/go
+`)
+	as.ok(err)
+	c.LayerConfigs()
+
+	as.ok(c.ValidateProfileEnv())
+
+	os.Setenv(PROFILE_ENV_VAR, `prod`)
+	as.ok(c.ValidateProfileEnv())
+
+	os.Setenv(PROFILE_ENV_VAR, DEFAULT_PROFILE)
+	as.ok(c.ValidateProfileEnv())
+
+	os.Setenv(PROFILE_ENV_VAR, `staging`)
+	as.err(`No such profile "staging" (from GOCDCLI_PROFILE); known profiles: [prod]`, c.ValidateProfileEnv())
+
+	os.Setenv(PROFILE_ENV_VAR, `Prod!`)
+	as.err(`Invalid profile name "Prod!"; profile names may only contain lowercase letters, digits, "-", and "_" (from GOCDCLI_PROFILE)`, c.ValidateProfileEnv())
+
+	// --profile takes precedence, so the environment does not matter
+	as.ok(c.SelectProfile(`prod`))
+	as.ok(c.ValidateProfileEnv())
+}
+
+func TestSetHelperAuth(t *testing.T) {
+	as := asserts(t)
+	c := testConf(true)
+
+	as.err(`Must specify auth helper command`, c.SetHelperAuth(` `))
+	as.ok(c.SetHelperAuth(`pass show gocd`))
+
+	as.configEq(inProfile(DEFAULT_PROFILE, dict{
+		"auth": map[string]string{
+			"command": "pass show gocd",
+			"type":    "helper",
+		},
+	}), c.fs)
+
+	as.deepEq(map[string]string{
+		"type":    "helper",
+		"command": "pass show gocd",
+	}, c.GetAuth())
+}
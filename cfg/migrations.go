@@ -16,7 +16,25 @@ import (
 //   from(1).to(2).do(`Name of migration`, func(schema dict) (dict, error) {
 //     ... perform schema changes here ...
 //   })
-var migrations = []*migration{}
+var migrations = []*migration{
+	from(1).to(2).do(`Move server and auth settings into the default profile`, func(schema dict) (dict, error) {
+		profile := dict{}
+
+		for _, key := range []string{`server`, `auth`} {
+			if v, ok := schema[key]; ok {
+				profile[key] = v
+				delete(schema, key)
+			}
+		}
+
+		if len(profile) > 0 {
+			schema[PROFILES_KEY] = dict{DEFAULT_PROFILE: profile}
+		}
+
+		schema[CURRENT_PROFILE_KEY] = DEFAULT_PROFILE
+		return schema, nil
+	}),
+}
 
 func applyMigrations(initial dict, migrations []*migration) (migrated dict, err error) {
 	migrated = initial
@@ -123,3 +123,38 @@ foo: bar
 		c.Migrate(migs),
 	)
 }
+
+func TestMigratesFlatSettingsIntoDefaultProfile(t *testing.T) {
+	as := asserts(t)
+	c, err := makeConf(`config_version: 1
+auth:
+  type: token
+  token: gah!
+server:
+  url: http://test/go
+`)
+	as.ok(err)
+
+	as.ok(c.Migrate(migrations))
+	as.configEq(dict{
+		CONFIG_VERSION:      2,
+		CURRENT_PROFILE_KEY: DEFAULT_PROFILE,
+		PROFILES_KEY: dict{
+			DEFAULT_PROFILE: dict{
+				`auth`: dict{
+					`token`: `gah!`,
+					`type`:  `token`,
+				},
+				`server`: dict{
+					`url`: `http://test/go`,
+				},
+			},
+		},
+	}, c.fs)
+
+	as.eq(`http://test/go`, c.GetServerUrl())
+	as.deepEq(map[string]string{
+		`type`:  `token`,
+		`token`: `gah!`,
+	}, c.GetAuth())
+}
@@ -0,0 +1,68 @@
+package cfg
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/gocd-contrib/gocd-cli/utils"
+)
+
+const PROXY_KEY = `proxy`
+
+// Proxy settings apply to all outbound requests, regardless of profile
+type ProxySettings struct {
+	HTTP    string
+	HTTPS   string
+	NoProxy string
+}
+
+// Returns the configured proxy settings, falling back to the conventional
+// HTTP_PROXY, HTTPS_PROXY, and NO_PROXY environment variables for any
+// setting not present in the config
+func (c *Config) GetProxy() *ProxySettings {
+	return &ProxySettings{
+		HTTP:    c.proxySetting(`http`, `HTTP_PROXY`),
+		HTTPS:   c.proxySetting(`https`, `HTTPS_PROXY`),
+		NoProxy: c.proxySetting(`no_proxy`, `NO_PROXY`),
+	}
+}
+
+// Sets the proxy for either `http` or `https` requests
+func (c *Config) SetProxy(scheme, proxyUrl string) error {
+	if `http` != scheme && `https` != scheme {
+		return fmt.Errorf(`Unknown proxy scheme %q; must be one of: http, https`, scheme)
+	}
+
+	if "" == proxyUrl {
+		return errors.New(`Must specify a proxy url`)
+	}
+
+	if u, err := url.Parse(proxyUrl); err != nil {
+		return utils.InspectError(err, `parsing proxy url %q`, proxyUrl)
+	} else if !u.IsAbs() || "" == u.Hostname() {
+		return errors.New(`proxy url must include protocol and hostname`)
+	}
+
+	return utils.InspectError(c.setValue(PROXY_KEY+`.`+scheme, proxyUrl), `writing %s proxy to config`, scheme)
+}
+
+// Sets a comma-separated list of hosts, domains, IPs, and CIDR blocks which
+// should bypass the proxy
+func (c *Config) SetNoProxy(hosts string) error {
+	return utils.InspectError(c.setValue(PROXY_KEY+`.no_proxy`, hosts), `writing no_proxy to config`)
+}
+
+func (c *Config) proxySetting(key, envVar string) string {
+	if val := c.native.GetString(PROXY_KEY + `.` + key); "" != val {
+		return val
+	}
+
+	if val := os.Getenv(envVar); "" != val {
+		return val
+	}
+
+	return os.Getenv(strings.ToLower(envVar))
+}
@@ -0,0 +1,58 @@
+package cfg
+
+import (
+	"os"
+	"testing"
+)
+
+func TestSetProxy(t *testing.T) {
+	as := asserts(t)
+	c := testConf(true)
+
+	as.ok(c.SetProxy(`http`, `http://proxy:3128`))
+	as.ok(c.SetProxy(`https`, `http://secure-proxy:3128`))
+	as.ok(c.SetNoProxy(`.internal,10.0.0.0/8`))
+
+	as.err(`Unknown proxy scheme "ftp"; must be one of: http, https`, c.SetProxy(`ftp`, `http://proxy`))
+	as.err(`proxy url must include protocol and hostname`, c.SetProxy(`http`, `proxy`))
+
+	as.configEq(dict{
+		`proxy`: dict{
+			`http`:     `http://proxy:3128`,
+			`https`:    `http://secure-proxy:3128`,
+			`no_proxy`: `.internal,10.0.0.0/8`,
+		},
+	}, c.fs)
+
+	// proxy settings are global, so they are shared by all profiles
+	as.ok(c.SelectProfile(`other`))
+	as.deepEq(&ProxySettings{
+		HTTP:    `http://proxy:3128`,
+		HTTPS:   `http://secure-proxy:3128`,
+		NoProxy: `.internal,10.0.0.0/8`,
+	}, c.GetProxy())
+
+	as.ok(c.Unset(`proxy`))
+	as.configEq(dict{}, c.fs)
+}
+
+func TestProxyFallsBackToConventionalEnvVars(t *testing.T) {
+	os.Clearenv()
+
+	defer os.Clearenv()
+
+	as := asserts(t)
+	c := testConf(true)
+
+	os.Setenv(`HTTP_PROXY`, `http://env-proxy:3128`)
+	os.Setenv(`https_proxy`, `http://lower-env-proxy:3128`)
+	os.Setenv(`NO_PROXY`, `localhost`)
+
+	as.ok(c.SetProxy(`http`, `http://proxy:3128`))
+
+	as.deepEq(&ProxySettings{
+		HTTP:    `http://proxy:3128`,
+		HTTPS:   `http://lower-env-proxy:3128`,
+		NoProxy: `localhost`,
+	}, c.GetProxy())
+}
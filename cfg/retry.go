@@ -0,0 +1,83 @@
+package cfg
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gocd-contrib/gocd-cli/utils"
+)
+
+const (
+	RETRY_KEY = `retry`
+
+	DEFAULT_RETRY_MAX_ATTEMPTS = 3
+	DEFAULT_RETRY_BASE_DELAY   = 500 * time.Millisecond
+	DEFAULT_RETRY_JITTER       = 0.2
+)
+
+// Governs how API requests are retried after transient failures; applies
+// to all profiles
+type RetrySettings struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	Jitter      float64
+}
+
+func (c *Config) GetRetry() *RetrySettings {
+	r := &RetrySettings{
+		MaxAttempts: DEFAULT_RETRY_MAX_ATTEMPTS,
+		BaseDelay:   DEFAULT_RETRY_BASE_DELAY,
+		Jitter:      DEFAULT_RETRY_JITTER,
+	}
+
+	if c.native.IsSet(RETRY_KEY + `.max_attempts`) {
+		r.MaxAttempts = max(c.native.GetInt(RETRY_KEY+`.max_attempts`), 1)
+	}
+
+	if d := c.native.GetDuration(RETRY_KEY + `.base_delay`); d > 0 {
+		r.BaseDelay = d
+	}
+
+	if c.native.IsSet(RETRY_KEY + `.jitter`) {
+		r.Jitter = c.native.GetFloat64(RETRY_KEY + `.jitter`)
+	}
+
+	return r
+}
+
+// Persists the retry settings; a maxAttempts of 1 disables retries
+func (c *Config) SetRetry(maxAttempts int, baseDelay time.Duration, jitter float64) error {
+	if maxAttempts < 1 {
+		return errors.New(`max_attempts must be at least 1`)
+	}
+
+	if baseDelay <= 0 {
+		return errors.New(`base_delay must be a positive duration (e.g., 500ms, 2s)`)
+	}
+
+	if jitter < 0 || jitter > 1 {
+		return errors.New(`jitter must be between 0.0 and 1.0`)
+	}
+
+	return c.writeConfigExcludingKey(RETRY_KEY, func(cfg dict) error {
+		cfg[RETRY_KEY+`.max_attempts`] = maxAttempts
+		cfg[RETRY_KEY+`.base_delay`] = baseDelay.String()
+		cfg[RETRY_KEY+`.jitter`] = jitter
+		return nil
+	})
+}
+
+// Overrides the number of retries (i.e., attempts after the first) for this
+// invocation without writing to the config file; used by the `--retries` flag
+func (c *Config) OverrideRetries(retries int) error {
+	if retries < 0 {
+		return errors.New(`--retries must not be negative`)
+	}
+
+	key := RETRY_KEY + `.max_attempts`
+	c.native.Set(key, retries+1)
+	c.overrides[key] = true
+	utils.Debug(`Overriding %s: %s`, key, strconv.Itoa(retries+1))
+	return nil
+}
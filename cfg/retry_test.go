@@ -0,0 +1,77 @@
+package cfg
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestRetryDefaults(t *testing.T) {
+	as := asserts(t)
+	c := testConf(true)
+
+	as.deepEq(&RetrySettings{
+		MaxAttempts: DEFAULT_RETRY_MAX_ATTEMPTS,
+		BaseDelay:   DEFAULT_RETRY_BASE_DELAY,
+		Jitter:      DEFAULT_RETRY_JITTER,
+	}, c.GetRetry())
+}
+
+func TestSetRetry(t *testing.T) {
+	as := asserts(t)
+	c := testConf(true)
+
+	as.ok(c.SetRetry(5, 2*time.Second, 0.5))
+
+	as.configEq(dict{
+		`retry`: dict{
+			`max_attempts`: 5,
+			`base_delay`:   `2s`,
+			`jitter`:       0.5,
+		},
+	}, c.fs)
+
+	as.deepEq(&RetrySettings{MaxAttempts: 5, BaseDelay: 2 * time.Second, Jitter: 0.5}, c.GetRetry())
+
+	as.err(`max_attempts must be at least 1`, c.SetRetry(0, time.Second, 0))
+	as.err(`base_delay must be a positive duration (e.g., 500ms, 2s)`, c.SetRetry(1, 0, 0))
+	as.err(`jitter must be between 0.0 and 1.0`, c.SetRetry(1, time.Second, 1.5))
+
+	as.ok(c.Unset(`retry`))
+	as.configEq(dict{}, c.fs)
+	as.eq(DEFAULT_RETRY_MAX_ATTEMPTS, c.GetRetry().MaxAttempts)
+}
+
+func TestOverrideRetries(t *testing.T) {
+	os.Clearenv()
+
+	defer os.Clearenv()
+
+	as := asserts(t)
+	c := testConf(true)
+	c.LayerConfigs()
+
+	as.ok(c.SetRetry(5, time.Second, 0))
+
+	os.Setenv(`GOCDCLI_RETRY.MAX_ATTEMPTS`, `2`)
+	as.eq(2, c.GetRetry().MaxAttempts)
+
+	as.ok(c.OverrideRetries(0))
+	as.eq(1, c.GetRetry().MaxAttempts)
+
+	s, err := c.Lookup(`retry.max_attempts`)
+	as.ok(err)
+	as.eq(`1`, s.Value)
+	as.eq(SOURCE_FLAG, s.Source)
+
+	as.err(`--retries must not be negative`, c.OverrideRetries(-1))
+
+	// the override is never written to disk
+	as.configEq(dict{
+		`retry`: dict{
+			`max_attempts`: 5,
+			`base_delay`:   `1s`,
+			`jitter`:       0,
+		},
+	}, c.fs)
+}
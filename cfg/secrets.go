@@ -0,0 +1,323 @@
+package cfg
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/afero"
+)
+
+const (
+	ENCRYPT_SECRETS_KEY = `secrets.encrypt`
+	PASSPHRASE_ENV_VAR  = `GOCDCLI_PASSPHRASE`
+	KEY_FILENAME        = `secret.key`
+
+	ENCRYPTED_PFX     = `enc:`
+	KEYFILE_SCHEME    = `key`
+	PASSPHRASE_SCHEME = `pass`
+
+	keySize       = 32 // AES-256
+	saltSize      = 16
+	kdfIterations = 210000
+)
+
+// keys of secrets that will be encrypted at rest, relative to a profile
+var secretKeys = []string{`auth.password`, `auth.token`}
+
+// PBKDF2 is intentionally slow; remember derived keys for this invocation
+var derivedKeys = struct {
+	sync.Mutex
+	cache map[string][]byte
+}{cache: make(map[string][]byte)}
+
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, ENCRYPTED_PFX)
+}
+
+// Encrypts all plaintext secrets in the config file, across all profiles, and
+// marks the file so that secrets set in the future are also encrypted.
+// Returns the number of secrets that were encrypted.
+func (c *Config) EncryptSecrets() (count int, err error) {
+	err = utils.InspectError(c.writeConfigExcludingKey(ENCRYPT_SECRETS_KEY, func(cfg dict) error {
+		for k, v := range cfg {
+			if plain, ok := v.(string); ok && isSecretKey(k) && !IsEncrypted(plain) {
+				if encrypted, err := c.encrypt(plain); err == nil {
+					cfg[k] = encrypted
+					count++
+				} else {
+					return err
+				}
+			}
+		}
+
+		cfg[ENCRYPT_SECRETS_KEY] = true
+		return nil
+	}), `encrypting secrets in config file`)
+
+	if err != nil {
+		count = 0
+	}
+	return
+}
+
+func (c *Config) encryptsSecrets() bool {
+	return c.native.GetBool(ENCRYPT_SECRETS_KEY)
+}
+
+// Encrypts a secret for storage if the config file has opted into encryption
+func (c *Config) sealIfEnabled(value string) (string, error) {
+	if c.encryptsSecrets() {
+		return c.encrypt(value)
+	}
+	return value, nil
+}
+
+// Returns plaintext values as-is so that environment variable overrides and
+// files that have not opted into encryption continue to work
+func (c *Config) decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(value, ENCRYPTED_PFX), `:`, 2)
+
+	if len(parts) != 2 {
+		return "", errors.New(`Malformed encrypted value`)
+	}
+
+	payload, err := base64.StdEncoding.DecodeString(parts[1])
+
+	if err != nil {
+		return "", utils.InspectError(err, `decoding encrypted value`)
+	}
+
+	var key []byte
+
+	switch parts[0] {
+	case KEYFILE_SCHEME:
+		if key, err = c.readKeyFile(); err != nil {
+			return "", err
+		}
+	case PASSPHRASE_SCHEME:
+		if len(payload) < saltSize {
+			return "", errors.New(`Malformed encrypted value`)
+		}
+
+		if key, err = passphraseKey(payload[:saltSize]); err != nil {
+			return "", err
+		}
+
+		payload = payload[saltSize:]
+	default:
+		return "", fmt.Errorf(`Unknown encryption scheme %q`, parts[0])
+	}
+
+	plain, err := open(key, payload)
+
+	if err != nil {
+		return "", utils.InspectError(err, `decrypting value with scheme %q`, parts[0])
+	}
+
+	return string(plain), nil
+}
+
+// Uses the passphrase from GOCDCLI_PASSPHRASE if set; otherwise, uses
+// (and creates, if necessary) the local key file
+func (c *Config) encrypt(value string) (string, error) {
+	var prefix, key []byte
+	var scheme string
+	var err error
+
+	if _, ok := os.LookupEnv(PASSPHRASE_ENV_VAR); ok {
+		scheme = PASSPHRASE_SCHEME
+		prefix = make([]byte, saltSize)
+
+		if _, err = rand.Read(prefix); err != nil {
+			return "", err
+		}
+
+		if key, err = passphraseKey(prefix); err != nil {
+			return "", err
+		}
+	} else {
+		scheme = KEYFILE_SCHEME
+
+		if key, err = c.readOrCreateKeyFile(); err != nil {
+			return "", err
+		}
+	}
+
+	sealed, err := seal(key, []byte(value))
+
+	if err != nil {
+		return "", utils.InspectError(err, `encrypting value with scheme %q`, scheme)
+	}
+
+	return ENCRYPTED_PFX + scheme + `:` + base64.StdEncoding.EncodeToString(append(prefix, sealed...)), nil
+}
+
+func passphraseKey(salt []byte) ([]byte, error) {
+	passphrase := os.Getenv(PASSPHRASE_ENV_VAR)
+
+	if "" == passphrase {
+		return nil, fmt.Errorf(`%s must be set to decrypt secrets that were encrypted with a passphrase`, PASSPHRASE_ENV_VAR)
+	}
+
+	derivedKeys.Lock()
+	defer derivedKeys.Unlock()
+
+	cacheKey := passphrase + string(salt)
+
+	if key, ok := derivedKeys.cache[cacheKey]; ok {
+		return key, nil
+	}
+
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, kdfIterations, keySize)
+
+	if err == nil {
+		derivedKeys.cache[cacheKey] = key
+	}
+
+	return key, err
+}
+
+func seal(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func open(key, payload []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(payload) < gcm.NonceSize() {
+		return nil, errors.New(`Malformed encrypted value`)
+	}
+
+	return gcm.Open(nil, payload[:gcm.NonceSize()], payload[gcm.NonceSize():], nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// The key file lives beside the config file whose secrets it encrypts
+func (c *Config) keyFilePath() (string, error) {
+	dir, err := c.configDir()
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, KEY_FILENAME), nil
+}
+
+func (c *Config) readKeyFile() ([]byte, error) {
+	path, err := c.keyFilePath()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if info, err := c.fs.Stat(path); err != nil {
+		return nil, utils.InspectError(err, `reading key file %q`, path)
+	} else if `windows` != runtime.GOOS && info.Mode().Perm()&0077 != 0 {
+		return nil, fmt.Errorf(`Key file %q must only be accessible by its owner; run: chmod 600 %q`, path, path)
+	}
+
+	content, err := afero.ReadFile(c.fs, path)
+
+	if err != nil {
+		return nil, utils.InspectError(err, `reading key file %q`, path)
+	}
+
+	key, err := hex.DecodeString(strings.TrimSpace(string(content)))
+
+	if err != nil || len(key) != keySize {
+		return nil, fmt.Errorf(`Key file %q is corrupt`, path)
+	}
+
+	return key, nil
+}
+
+func (c *Config) readOrCreateKeyFile() ([]byte, error) {
+	path, err := c.keyFilePath()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if exists, err := afero.Exists(c.fs, path); err != nil {
+		return nil, err
+	} else if exists {
+		return c.readKeyFile()
+	}
+
+	utils.Debug(`Creating new key file at %q`, path)
+
+	key := make([]byte, keySize)
+
+	if _, err = rand.Read(key); err != nil {
+		return nil, err
+	}
+
+	if err = c.fs.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return nil, utils.InspectError(err, `creating directory for key file %q`, path)
+	}
+
+	f, err := c.fs.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+
+	if err != nil {
+		return nil, utils.InspectError(err, `creating key file %q`, path)
+	}
+
+	defer f.Close()
+
+	if _, err = f.WriteString(hex.EncodeToString(key) + "\n"); err != nil {
+		return nil, utils.InspectError(err, `writing key file %q`, path)
+	}
+
+	return key, nil
+}
+
+func isSecretKey(key string) bool {
+	for _, s := range secretKeys {
+		if key == s || strings.HasSuffix(key, `.`+s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,176 @@
+package cfg
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+)
+
+const TEST_MULTI_PROFILE_CONF = `---
+config_version: 2
+profiles:
+  default:
+    auth:
+      type: basic
+      user: admin
+      password: badger
+  prod:
+    auth:
+      type: token
+      token: prod-token
+`
+
+func TestEncryptSecretsWithKeyFile(t *testing.T) {
+	os.Unsetenv(PASSPHRASE_ENV_VAR)
+
+	as := asserts(t)
+	c, err := makeConf(TEST_MULTI_PROFILE_CONF)
+	as.ok(err)
+
+	count, err := c.EncryptSecrets()
+	as.ok(err)
+	as.eq(2, count)
+
+	ro, err := c.fsOnlyViper(c.fs)
+	as.ok(err)
+	as.is(ro.GetBool(ENCRYPT_SECRETS_KEY))
+	as.is(strings.HasPrefix(ro.GetString(`profiles.default.auth.password`), `enc:key:`))
+	as.is(strings.HasPrefix(ro.GetString(`profiles.prod.auth.token`), `enc:key:`))
+	as.eq(`admin`, ro.GetString(`profiles.default.auth.user`))
+
+	path, err := c.keyFilePath()
+	as.ok(err)
+	info, err := c.fs.Stat(path)
+	as.ok(err)
+	as.eq(os.FileMode(0600), info.Mode().Perm())
+
+	as.deepEq(map[string]string{
+		`type`:     `basic`,
+		`user`:     `admin`,
+		`password`: `badger`,
+	}, c.GetAuth())
+
+	as.ok(c.SelectProfile(`prod`))
+	as.deepEq(map[string]string{
+		`type`:  `token`,
+		`token`: `prod-token`,
+	}, c.GetAuth())
+
+	// already encrypted values are left alone
+	count, err = c.EncryptSecrets()
+	as.ok(err)
+	as.eq(0, count)
+}
+
+func TestSetAuthEncryptsOnceEnabled(t *testing.T) {
+	os.Unsetenv(PASSPHRASE_ENV_VAR)
+
+	as := asserts(t)
+	c, err := makeConf(TEST_MULTI_PROFILE_CONF)
+	as.ok(err)
+
+	as.ok(c.SetTokenAuth(`plain`))
+	as.eq(`plain`, c.native.GetString(`profiles.default.auth.token`))
+
+	_, err = c.EncryptSecrets()
+	as.ok(err)
+
+	as.ok(c.SetTokenAuth(`sealed`))
+	as.is(IsEncrypted(c.native.GetString(`profiles.default.auth.token`)))
+	as.deepEq(map[string]string{
+		`type`:  `token`,
+		`token`: `sealed`,
+	}, c.GetAuth())
+
+	s, err := c.Lookup(`auth.token`)
+	as.ok(err)
+	as.eq(`sealed`, s.Value)
+}
+
+func TestEncryptSecretsWithPassphrase(t *testing.T) {
+	defer os.Unsetenv(PASSPHRASE_ENV_VAR)
+
+	as := asserts(t)
+	c, err := makeConf(TEST_MULTI_PROFILE_CONF)
+	as.ok(err)
+
+	os.Setenv(PASSPHRASE_ENV_VAR, `open sesame`)
+
+	_, err = c.EncryptSecrets()
+	as.ok(err)
+	as.is(strings.HasPrefix(c.native.GetString(`profiles.default.auth.password`), `enc:pass:`))
+	as.eq(`badger`, c.GetAuth()[`password`])
+
+	os.Setenv(PASSPHRASE_ENV_VAR, `wrong`)
+	_, err = c.decrypt(c.native.GetString(`profiles.default.auth.password`))
+	as.err(`cipher: message authentication failed`, err)
+
+	os.Unsetenv(PASSPHRASE_ENV_VAR)
+	_, err = c.decrypt(c.native.GetString(`profiles.default.auth.password`))
+	as.err(`GOCDCLI_PASSPHRASE must be set to decrypt secrets that were encrypted with a passphrase`, err)
+	as.eq(``, c.GetAuth()[`password`])
+}
+
+func TestEnvironmentOverridesAreNotDecrypted(t *testing.T) {
+	os.Unsetenv(PASSPHRASE_ENV_VAR)
+	defer os.Unsetenv(`GOCDCLI_AUTH.PASSWORD`)
+
+	as := asserts(t)
+	c, err := makeConf(TEST_MULTI_PROFILE_CONF)
+	as.ok(err)
+	c.LayerConfigs()
+
+	_, err = c.EncryptSecrets()
+	as.ok(err)
+
+	os.Setenv(`GOCDCLI_AUTH.PASSWORD`, `from-env`)
+	as.eq(`from-env`, c.GetAuth()[`password`])
+}
+
+func TestKeyFileMustBePrivate(t *testing.T) {
+	os.Unsetenv(PASSPHRASE_ENV_VAR)
+
+	as := asserts(t)
+	c, err := makeConf(TEST_MULTI_PROFILE_CONF)
+	as.ok(err)
+
+	_, err = c.EncryptSecrets()
+	as.ok(err)
+
+	path, err := c.keyFilePath()
+	as.ok(err)
+	as.ok(c.fs.Chmod(path, 0644))
+
+	_, err = c.decrypt(c.native.GetString(`profiles.default.auth.password`))
+	as.err(`Key file "`+path+`" must only be accessible by its owner; run: chmod 600 "`+path+`"`, err)
+
+	as.ok(afero.WriteFile(c.fs, path, []byte(`not a key`), 0600))
+	as.ok(c.fs.Chmod(path, 0600))
+	_, err = c.decrypt(c.native.GetString(`profiles.default.auth.password`))
+	as.err(`Key file "`+path+`" is corrupt`, err)
+}
+
+func TestKeyFileLivesBesideConfigFile(t *testing.T) {
+	os.Unsetenv(PASSPHRASE_ENV_VAR)
+
+	as := asserts(t)
+	fs := afero.NewMemMapFs()
+	c := NewConfig(fs)
+
+	as.ok(writeContent(fs, `/alt/gocd.yaml`, TEST_MULTI_PROFILE_CONF))
+	c.native.SetConfigFile(`/alt/gocd.yaml`)
+	as.ok(c.native.ReadInConfig())
+
+	_, err := c.EncryptSecrets()
+	as.ok(err)
+
+	path, err := c.keyFilePath()
+	as.ok(err)
+	as.eq(`/alt/secret.key`, path)
+
+	exists, err := afero.Exists(fs, path)
+	as.ok(err)
+	as.is(exists)
+}
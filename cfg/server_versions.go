@@ -0,0 +1,128 @@
+package cfg
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/afero"
+)
+
+const (
+	SERVER_VERSIONS_FILENAME = `server-versions.json`
+
+	// How long a detected server version is trusted before it is checked
+	// again; servers are upgraded in place, so this should not be too long
+	SERVER_VERSION_TTL = 24 * time.Hour
+)
+
+// overridden in tests
+var now = time.Now
+
+type serverVersionEntry struct {
+	Version   string    `json:"version"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Returns the version of the GoCD server at serverUrl as detected within
+// SERVER_VERSION_TTL, or an empty string if there is none
+func (c *Config) CachedServerVersion(serverUrl string) string {
+	entries, err := c.readServerVersions()
+
+	if err != nil {
+		utils.Debug(`ignoring unreadable server version cache: %v`, err)
+		return ""
+	}
+
+	if e, ok := entries[serverVersionKey(serverUrl)]; ok && now().Sub(e.CheckedAt) < SERVER_VERSION_TTL {
+		return e.Version
+	}
+
+	return ""
+}
+
+// Records the detected version of the GoCD server at serverUrl
+func (c *Config) CacheServerVersion(serverUrl, version string) error {
+	entries, err := c.readServerVersions()
+
+	if err != nil {
+		entries = make(map[string]*serverVersionEntry) // start over
+	}
+
+	entries[serverVersionKey(serverUrl)] = &serverVersionEntry{Version: version, CheckedAt: now().UTC()}
+	return c.writeServerVersions(entries)
+}
+
+// Discards the cached version of the GoCD server at serverUrl, e.g., when
+// the server turns out to have been upgraded or downgraded
+func (c *Config) ForgetServerVersion(serverUrl string) error {
+	entries, err := c.readServerVersions()
+
+	if err != nil {
+		entries = make(map[string]*serverVersionEntry) // start over
+	} else if _, ok := entries[serverVersionKey(serverUrl)]; !ok {
+		return nil
+	}
+
+	delete(entries, serverVersionKey(serverUrl))
+	return c.writeServerVersions(entries)
+}
+
+func (c *Config) writeServerVersions(entries map[string]*serverVersionEntry) error {
+	path, err := c.serverVersionsPath()
+
+	if err != nil {
+		return err
+	}
+
+	content, err := json.MarshalIndent(entries, ``, `  `)
+
+	if err != nil {
+		return utils.InspectError(err, `encoding server version cache`)
+	}
+
+	if err = c.fs.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return utils.InspectError(err, `creating directory %q`, filepath.Dir(path))
+	}
+
+	return utils.InspectError(afero.WriteFile(c.fs, path, content, 0644), `writing server version cache %q`, path)
+}
+
+func (c *Config) readServerVersions() (map[string]*serverVersionEntry, error) {
+	path, err := c.serverVersionsPath()
+
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string]*serverVersionEntry)
+	content, err := afero.ReadFile(c.fs, path)
+
+	if os.IsNotExist(err) {
+		return entries, nil
+	}
+
+	if err != nil {
+		return nil, utils.InspectError(err, `reading server version cache %q`, path)
+	}
+
+	return entries, utils.InspectError(json.Unmarshal(content, &entries), `parsing server version cache %q`, path)
+}
+
+func serverVersionKey(serverUrl string) string {
+	return strings.TrimRight(serverUrl, `/`)
+}
+
+// The cache lives beside the config file whose servers it describes
+func (c *Config) serverVersionsPath() (string, error) {
+	dir, err := c.configDir()
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, SERVER_VERSIONS_FILENAME), nil
+}
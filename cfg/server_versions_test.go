@@ -0,0 +1,84 @@
+package cfg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+func TestServerVersionCache(t *testing.T) {
+	as := asserts(t)
+	c := testConf(true)
+
+	as.eq("", c.CachedServerVersion(`https://ci.example.com/go`))
+
+	as.ok(c.CacheServerVersion(`https://ci.example.com/go/`, `23.1.0`))
+	as.ok(c.CacheServerVersion(`https://other.example.com/go`, `20.1.0`))
+
+	as.eq(`23.1.0`, c.CachedServerVersion(`https://ci.example.com/go`))
+	as.eq(`20.1.0`, c.CachedServerVersion(`https://other.example.com/go`))
+}
+
+func TestServerVersionCacheExpires(t *testing.T) {
+	as := asserts(t)
+	c := testConf(true)
+
+	as.ok(c.CacheServerVersion(`https://ci.example.com/go`, `23.1.0`))
+
+	defer func(orig func() time.Time) { now = orig }(now)
+	now = func() time.Time { return time.Now().Add(SERVER_VERSION_TTL) }
+
+	as.eq("", c.CachedServerVersion(`https://ci.example.com/go`))
+}
+
+func TestServerVersionCacheRecoversFromCorruptFile(t *testing.T) {
+	as := asserts(t)
+	c := testConf(true)
+
+	path, err := c.serverVersionsPath()
+	as.ok(err)
+	as.ok(afero.WriteFile(c.fs, path, []byte(`{not json`), 0644))
+
+	as.eq("", c.CachedServerVersion(`https://ci.example.com/go`))
+
+	as.ok(c.CacheServerVersion(`https://ci.example.com/go`, `23.1.0`))
+	as.eq(`23.1.0`, c.CachedServerVersion(`https://ci.example.com/go`))
+}
+
+func TestForgetServerVersion(t *testing.T) {
+	as := asserts(t)
+	c := testConf(true)
+
+	as.ok(c.ForgetServerVersion(`https://ci.example.com/go`))
+
+	as.ok(c.CacheServerVersion(`https://ci.example.com/go`, `23.1.0`))
+	as.ok(c.CacheServerVersion(`https://other.example.com/go`, `20.1.0`))
+
+	as.ok(c.ForgetServerVersion(`https://ci.example.com/go/`))
+	as.eq("", c.CachedServerVersion(`https://ci.example.com/go`))
+	as.eq(`20.1.0`, c.CachedServerVersion(`https://other.example.com/go`))
+}
+
+func TestServerVersionCacheLivesBesideConfigFile(t *testing.T) {
+	as := asserts(t)
+	fs := afero.NewMemMapFs()
+	c := NewConfig(fs)
+
+	as.ok(writeContent(fs, `/alt/gocd.yaml`, "---\nconfig_version: 2\n"))
+	c.native.SetConfigFile(`/alt/gocd.yaml`)
+	as.ok(c.native.ReadInConfig())
+
+	as.ok(c.CacheServerVersion(`https://ci.example.com/go`, `23.1.0`))
+
+	exists, err := afero.Exists(fs, `/alt/`+SERVER_VERSIONS_FILENAME)
+	as.ok(err)
+	as.is(exists)
+
+	// another config file does not share the cache
+	other := NewConfig(fs)
+	as.ok(writeContent(fs, `/other/gocd.yaml`, "---\nconfig_version: 2\n"))
+	other.native.SetConfigFile(`/other/gocd.yaml`)
+	as.ok(other.native.ReadInConfig())
+	as.eq("", other.CachedServerVersion(`https://ci.example.com/go`))
+}
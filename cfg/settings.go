@@ -0,0 +1,182 @@
+package cfg
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gocd-contrib/gocd-cli/utils"
+)
+
+const (
+	SOURCE_FILE    = `file`
+	SOURCE_ENV     = `env`
+	SOURCE_FLAG    = `flag`
+	SOURCE_DEFAULT = `default`
+
+	PROFILE_SETTING = `profile`
+	REDACTED        = `********`
+)
+
+// A resolved configuration value, along with where it came from
+type Setting struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+	Secret bool   `json:"secret,omitempty"`
+}
+
+func (s *Setting) IsSet() bool {
+	return `` != s.Value
+}
+
+// Returns a copy of this Setting with its value masked if it is a secret, or
+// with the password masked if it is a URL with credentials, e.g., a proxy
+func (s *Setting) Redacted() *Setting {
+	if !s.IsSet() {
+		return s
+	}
+
+	if s.Secret {
+		return &Setting{Key: s.Key, Value: REDACTED, Source: s.Source, Secret: s.Secret}
+	}
+
+	if masked, ok := redactUrlPassword(s.Value); ok {
+		return &Setting{Key: s.Key, Value: masked, Source: s.Source, Secret: s.Secret}
+	}
+
+	return s
+}
+
+func redactUrlPassword(value string) (string, bool) {
+	u, err := url.Parse(value)
+
+	if err != nil || nil == u.User {
+		return value, false
+	}
+
+	if _, ok := u.User.Password(); !ok {
+		return value, false
+	}
+
+	// url.UserPassword() would percent-encode the mask
+	user := url.User(u.User.Username()).String()
+	u.User = url.User(u.User.Username())
+	return strings.Replace(u.String(), user+`@`, user+`:`+REDACTED+`@`, 1), true
+}
+
+type settingSpec struct {
+	key    string
+	secret bool
+	def    string // reported when the setting is unset
+}
+
+// The settings reported by `Settings()`; keys are relative to the active profile,
+// except for global settings such as `proxy.*`
+var knownSettings = []settingSpec{
+	{key: `server.url`},
+	{key: `server.tls.ca_file`},
+	{key: `server.tls.client_cert`},
+	{key: `server.tls.client_key`},
+	{key: `server.tls.insecure_skip_verify`},
+	{key: `auth.type`},
+	{key: `auth.user`},
+	{key: `auth.password`, secret: true},
+	{key: `auth.token`, secret: true},
+	{key: `auth.command`},
+	{key: `proxy.http`},
+	{key: `proxy.https`},
+	{key: `proxy.no_proxy`},
+	{key: `retry.max_attempts`, def: strconv.Itoa(DEFAULT_RETRY_MAX_ATTEMPTS)},
+	{key: `retry.base_delay`, def: DEFAULT_RETRY_BASE_DELAY.String()},
+	{key: `retry.jitter`, def: strconv.FormatFloat(DEFAULT_RETRY_JITTER, 'f', -1, 64)},
+}
+
+func SettingKeys() []string {
+	keys := []string{PROFILE_SETTING}
+	for _, s := range knownSettings {
+		keys = append(keys, s.key)
+	}
+	return keys
+}
+
+// Resolves all known settings for the active profile
+func (c *Config) Settings() []*Setting {
+	result := make([]*Setting, 0, len(knownSettings)+1)
+
+	for _, key := range SettingKeys() {
+		if s, err := c.Lookup(key); err == nil {
+			result = append(result, s)
+		}
+	}
+
+	return result
+}
+
+// Resolves a single setting by key (e.g., `server.url`) for the active profile
+func (c *Config) Lookup(key string) (*Setting, error) {
+	key = strings.ToLower(key)
+
+	if PROFILE_SETTING == key {
+		return &Setting{Key: key, Value: c.ActiveProfile(), Source: c.sourceOf(CURRENT_PROFILE_KEY, PROFILE_ENV_VAR, CURRENT_PROFILE_KEY)}, nil
+	}
+
+	for _, spec := range knownSettings {
+		if spec.key == key {
+			value := c.getString(key)
+
+			if spec.secret {
+				if plain, err := c.decrypt(value); err == nil {
+					value = plain
+				} else {
+					utils.InspectError(err, `decrypting %q; displaying the encrypted value`, key)
+				}
+			}
+
+			source := c.sourceOf(key, envVarFor(key), key, c.profileKey(key))
+
+			if `` == value && SOURCE_DEFAULT == source {
+				value = spec.def
+			}
+
+			return &Setting{
+				Key:    key,
+				Value:  value,
+				Source: source,
+				Secret: spec.secret,
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf(`Unknown key %q; known keys: %s`, key, strings.Join(SettingKeys(), `, `))
+}
+
+// Determines where a value is sourced from, following Viper's order of precedence
+func (c *Config) sourceOf(key, envVar string, fileKeys ...string) string {
+	if c.overrides[key] {
+		return SOURCE_FLAG
+	}
+
+	if val, ok := os.LookupEnv(envVar); ok && `` != val {
+		return SOURCE_ENV
+	}
+
+	if ro, err := c.fsOnlyViper(c.fs); err == nil {
+		for _, k := range fileKeys {
+			if ro.IsSet(k) {
+				return SOURCE_FILE
+			}
+		}
+	} else {
+		utils.InspectError(err, `reading config file to determine source of %q`, key)
+	}
+
+	return SOURCE_DEFAULT
+}
+
+// The environment variable Viper consults for a given key
+func envVarFor(key string) string {
+	return strings.ToUpper(CONFIG_ENV_PFX + `_` + key)
+}
@@ -54,6 +54,15 @@ func testConf(create bool) *Config {
 	return c
 }
 
+// nests settings under the named profile, as they are laid out on disk
+func inProfile(name string, settings dict) dict {
+	return dict{
+		PROFILES_KEY: dict{
+			name: settings,
+		},
+	}
+}
+
 func serialize(t *testing.T, v interface{}) string {
 	if b, err := yaml.Marshal(v); err != nil {
 		t.Errorf("Error while trying to marshal %v: %v", v, err)
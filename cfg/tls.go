@@ -0,0 +1,86 @@
+package cfg
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/afero"
+)
+
+const TLS_KEY = `server.tls`
+
+// TLS settings for connecting to the GoCD server of the active profile
+type TLSSettings struct {
+	CAFile             string
+	ClientCert         string
+	ClientKey          string
+	InsecureSkipVerify bool
+}
+
+func (c *Config) GetTLS() *TLSSettings {
+	return &TLSSettings{
+		CAFile:             c.getString(TLS_KEY + `.ca_file`),
+		ClientCert:         c.getString(TLS_KEY + `.client_cert`),
+		ClientKey:          c.getString(TLS_KEY + `.client_key`),
+		InsecureSkipVerify: c.getBool(TLS_KEY + `.insecure_skip_verify`),
+	}
+}
+
+// Sets a PEM bundle of CA certificates to trust in addition to the system's
+func (c *Config) SetTLSCAFile(path string) error {
+	if abs, err := c.existingFile(path); err != nil {
+		return err
+	} else {
+		return utils.InspectError(c.setProfileValue(TLS_KEY+`.ca_file`, abs), `writing tls ca_file to config`)
+	}
+}
+
+// Sets the PEM certificate and private key used for mutual TLS
+func (c *Config) SetTLSClientCert(certPath, keyPath string) error {
+	cert, err := c.existingFile(certPath)
+
+	if err != nil {
+		return err
+	}
+
+	key, err := c.existingFile(keyPath)
+
+	if err != nil {
+		return err
+	}
+
+	if err = c.setProfileValue(TLS_KEY+`.client_cert`, cert); err != nil {
+		return utils.InspectError(err, `writing tls client_cert to config`)
+	}
+
+	return utils.InspectError(c.setProfileValue(TLS_KEY+`.client_key`, key), `writing tls client_key to config`)
+}
+
+func (c *Config) SetTLSInsecureSkipVerify(insecure bool) error {
+	return utils.InspectError(c.setProfileValue(TLS_KEY+`.insecure_skip_verify`, insecure), `writing tls insecure_skip_verify to config`)
+}
+
+// Resolves a path to an absolute path, ensuring it refers to an existing file
+func (c *Config) existingFile(path string) (string, error) {
+	if "" == path {
+		return "", errors.New(`Must specify a file`)
+	}
+
+	abs, err := filepath.Abs(path)
+
+	if err != nil {
+		return "", utils.InspectError(err, `resolving absolute path of %q`, path)
+	}
+
+	if isFile, err := afero.Exists(c.fs, abs); err != nil || !isFile {
+		return "", fmt.Errorf(`File %q does not exist`, path)
+	}
+
+	if isDir, _ := afero.IsDir(c.fs, abs); isDir {
+		return "", fmt.Errorf(`%q is a directory`, path)
+	}
+
+	return abs, nil
+}
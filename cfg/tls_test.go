@@ -0,0 +1,53 @@
+package cfg
+
+import (
+	"testing"
+)
+
+func TestSetTLSSettings(t *testing.T) {
+	as := asserts(t)
+	c := testConf(true)
+
+	as.ok(writeContent(c.fs, `/certs/ca.pem`, `ca`))
+	as.ok(writeContent(c.fs, `/certs/client.crt`, `cert`))
+	as.ok(writeContent(c.fs, `/certs/client.key`, `key`))
+
+	as.ok(c.SetTLSCAFile(`/certs/ca.pem`))
+	as.ok(c.SetTLSClientCert(`/certs/client.crt`, `/certs/client.key`))
+	as.ok(c.SetTLSInsecureSkipVerify(true))
+
+	as.deepEq(&TLSSettings{
+		CAFile:             `/certs/ca.pem`,
+		ClientCert:         `/certs/client.crt`,
+		ClientKey:          `/certs/client.key`,
+		InsecureSkipVerify: true,
+	}, c.GetTLS())
+
+	as.configEq(inProfile(DEFAULT_PROFILE, dict{
+		`server`: dict{
+			`tls`: dict{
+				`ca_file`:              `/certs/ca.pem`,
+				`client_cert`:          `/certs/client.crt`,
+				`client_key`:           `/certs/client.key`,
+				`insecure_skip_verify`: true,
+			},
+		},
+	}), c.fs)
+
+	as.ok(c.Unset(`tls`))
+	as.deepEq(&TLSSettings{}, c.GetTLS())
+}
+
+func TestSetTLSFilesMustExist(t *testing.T) {
+	as := asserts(t)
+	c := testConf(true)
+
+	as.ok(writeContent(c.fs, `/certs/client.crt`, `cert`))
+	as.ok(c.fs.MkdirAll(`/certs/dir`, 0755))
+
+	as.err(`Must specify a file`, c.SetTLSCAFile(``))
+	as.err(`File "/nope.pem" does not exist`, c.SetTLSCAFile(`/nope.pem`))
+	as.err(`"/certs/dir" is a directory`, c.SetTLSCAFile(`/certs/dir`))
+	as.err(`File "/certs/client.key" does not exist`, c.SetTLSClientCert(`/certs/client.crt`, `/certs/client.key`))
+	as.deepEq(&TLSSettings{}, c.GetTLS())
+}
@@ -0,0 +1,58 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var DeleteCmd = &cobra.Command{
+	Use:     "delete <uuid|hostname|glob>...",
+	Aliases: []string{"rm"},
+	Short:   "Deletes agents; only disabled agents that are not building can be deleted",
+	Long: strings.Trim(`
+Deletes agents; only disabled agents that are not building can be deleted. As a
+glob may match more agents than intended, nothing is deleted without --yes; run
+without it first to review the selected agents.`, "\n"),
+	Example: strings.Trim(`
+  gocd agent delete 'old-*'
+  gocd agent disable 'old-*' && gocd agent delete 'old-*' --yes`, "\n"),
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return del.Run(cmd.Context(), args)
+	},
+}
+
+var del = &DeleteRunner{}
+
+type DeleteRunner struct {
+	Yes bool
+}
+
+func (r *DeleteRunner) Run(ctx context.Context, args []string) error {
+	agents, err := selectAgents(ctx, args)
+
+	if err != nil {
+		return err
+	}
+
+	if !r.Yes {
+		return errors.New(`Not deleting without --yes; check the selected agents above, then run again with --yes`)
+	}
+
+	if msg, err := api.DefaultClient.Agents.BulkDelete(ctx, api.AgentUuids(agents)); err != nil {
+		return err
+	} else {
+		utils.Echofln(msg)
+	}
+	return nil
+}
+
+func init() {
+	DeleteCmd.Flags().BoolVarP(&del.Yes, "yes", "y", false, "delete the selected agents; without it, only lists them")
+	RootCmd.AddCommand(DeleteCmd)
+}
@@ -0,0 +1,42 @@
+package agent
+
+import (
+	"context"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var DisableCmd = &cobra.Command{
+	Use:     "disable <uuid|hostname|glob>...",
+	Short:   "Disables agents, so that they are not assigned jobs",
+	Example: `  gocd agent disable 'build-*'`,
+	Args:    cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return disable.Run(cmd.Context(), args)
+	},
+}
+
+var disable = &DisableRunner{}
+
+type DisableRunner struct{}
+
+func (r *DisableRunner) Run(ctx context.Context, args []string) error {
+	agents, err := selectAgents(ctx, args)
+
+	if err != nil {
+		return err
+	}
+
+	if msg, err := api.DefaultClient.Agents.BulkUpdate(ctx, &api.AgentBulkUpdate{Uuids: api.AgentUuids(agents), AgentConfigState: `Disabled`}); err != nil {
+		return err
+	} else {
+		utils.Echofln(msg)
+	}
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(DisableCmd)
+}
@@ -0,0 +1,42 @@
+package agent
+
+import (
+	"context"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var EnableCmd = &cobra.Command{
+	Use:     "enable <uuid|hostname|glob>...",
+	Short:   "Enables agents, so that they can be assigned jobs",
+	Example: `  gocd agent enable 'build-*'`,
+	Args:    cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return enable.Run(cmd.Context(), args)
+	},
+}
+
+var enable = &EnableRunner{}
+
+type EnableRunner struct{}
+
+func (r *EnableRunner) Run(ctx context.Context, args []string) error {
+	agents, err := selectAgents(ctx, args)
+
+	if err != nil {
+		return err
+	}
+
+	if msg, err := api.DefaultClient.Agents.BulkUpdate(ctx, &api.AgentBulkUpdate{Uuids: api.AgentUuids(agents), AgentConfigState: `Enabled`}); err != nil {
+		return err
+	} else {
+		utils.Echofln(msg)
+	}
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(EnableCmd)
+}
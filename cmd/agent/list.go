@@ -0,0 +1,88 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var ListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "Lists agents, with their state, resources, and environments",
+	Example: `  gocd agent list --env production --state idle`,
+	Args:    cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return list.Run(cmd.Context(), args)
+	},
+}
+
+var list = &ListRunner{}
+
+type ListRunner struct {
+	Env      string
+	Resource string
+	State    string
+}
+
+func (r *ListRunner) Run(ctx context.Context, args []string) error {
+	agents, err := api.DefaultClient.Agents.List(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(utils.StdoutOrDevNull(), 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "HOSTNAME\tUUID\tIP ADDRESS\tSTATE\tRESOURCES\tENVIRONMENTS")
+
+	for _, a := range agents {
+		if r.matches(a) {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", a.Hostname, a.Uuid, a.IpAddress, describeState(a), strings.Join(a.Resources, `,`), strings.Join(a.EnvironmentNames(), `,`))
+		}
+	}
+
+	return w.Flush()
+}
+
+func (r *ListRunner) matches(a *api.Agent) bool {
+	if "" != r.Env && !contains(a.EnvironmentNames(), r.Env) {
+		return false
+	}
+
+	if "" != r.Resource && !contains(a.Resources, r.Resource) {
+		return false
+	}
+
+	if "" != r.State {
+		for _, s := range []string{a.AgentConfigState, a.AgentState, a.BuildState} {
+			if strings.EqualFold(r.State, s) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+// GoCD compares resource and environment names case-insensitively
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(name, n) {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	RootCmd.AddCommand(ListCmd)
+	ListCmd.Flags().StringVar(&list.Env, "env", "", "only list agents in this environment")
+	ListCmd.Flags().StringVar(&list.Resource, "resource", "", "only list agents with this resource")
+	ListCmd.Flags().StringVar(&list.State, "state", "", "only list agents in this state, e.g., enabled, disabled, pending, idle, building, lostcontact, or missing")
+}
@@ -0,0 +1,59 @@
+package agent
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+// RootCmd represents the agent command
+var RootCmd = &cobra.Command{
+	Use:   "agent",
+	Short: "GoCD agent operations",
+	Long: `List, inspect, enable, disable, update, and delete agents. Agents are selected by
+UUID, hostname, or hostname glob (e.g., "build-*"; quote it to keep the shell
+from expanding it).`,
+	ValidArgs: []string{"list", "show", "enable", "disable", "update", "delete", "help"}, // bash-completion
+}
+
+// Selects the agents matching the given UUIDs, hostnames, or hostname globs,
+// and reports which were selected
+func selectAgents(ctx context.Context, patterns []string) ([]*api.Agent, error) {
+	agents, err := api.DefaultClient.Agents.List(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	matched, err := api.MatchAgents(agents, patterns)
+
+	if err != nil {
+		return nil, err
+	}
+
+	utils.Echofln(`Selected %d agent(s): %s`, len(matched), hostnames(matched))
+	return matched, nil
+}
+
+func hostnames(agents []*api.Agent) string {
+	names := make([]string, 0, len(agents))
+	for _, a := range agents {
+		names = append(names, a.Hostname)
+	}
+	return strings.Join(names, `, `)
+}
+
+// e.g., `Enabled, Idle`
+func describeState(a *api.Agent) string {
+	return a.AgentConfigState + `, ` + a.AgentState
+}
+
+func orNone(names []string) string {
+	if 0 == len(names) {
+		return `(none)`
+	}
+	return strings.Join(names, `, `)
+}
@@ -0,0 +1,72 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var ShowCmd = &cobra.Command{
+	Use:   "show <uuid|hostname>",
+	Short: "Displays the details of an agent",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return show.Run(cmd.Context(), args)
+	},
+}
+
+var show = &ShowRunner{}
+
+type ShowRunner struct{}
+
+func (r *ShowRunner) Run(ctx context.Context, args []string) error {
+	agents, err := api.DefaultClient.Agents.List(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	matched, err := api.MatchAgents(agents, args)
+
+	if err != nil {
+		return err
+	}
+
+	if len(matched) > 1 {
+		return fmt.Errorf(`%q matches %d agents (%s); specify one by UUID or hostname`, args[0], len(matched), hostnames(matched))
+	}
+
+	a := matched[0]
+
+	utils.Echofln(`Hostname:       %s`, a.Hostname)
+	utils.Echofln(`UUID:           %s`, a.Uuid)
+	utils.Echofln(`IP address:     %s`, a.IpAddress)
+	utils.Echofln(`OS:             %s`, a.OperatingSystem)
+	utils.Echofln(`Sandbox:        %s`, a.Sandbox)
+	utils.Echofln(`Free space:     %s`, freeSpace(a.FreeSpace))
+	utils.Echofln(`State:          %s`, describeState(a))
+
+	if b := a.BuildDetails; nil != b {
+		utils.Echofln(`Building:       %s/%s/%s`, b.PipelineName, b.StageName, b.JobName)
+	}
+
+	utils.Echofln(`Resources:      %s`, orNone(a.Resources))
+	utils.Echofln(`Environments:   %s`, orNone(a.EnvironmentNames()))
+	return nil
+}
+
+// The server reports free space in bytes, or as `unknown`
+func freeSpace(v interface{}) string {
+	if n, ok := v.(float64); ok {
+		return humanize.Bytes(uint64(n))
+	}
+	return fmt.Sprint(v)
+}
+
+func init() {
+	RootCmd.AddCommand(ShowCmd)
+}
@@ -0,0 +1,71 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var UpdateCmd = &cobra.Command{
+	Use:   "update <uuid|hostname|glob>... [flags]",
+	Short: "Adds resources and environments to agents, or removes them",
+	Long: strings.Trim(`
+Adds resources and environments to agents, or removes them, in a single request
+for all the selected agents. Environments defined in config repos cannot be
+changed this way.`, "\n"),
+	Example: `  gocd agent update 'build-*' --add-resource docker --remove-resource legacy --add-env staging`,
+	Args:    cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return update.Run(cmd.Context(), args)
+	},
+}
+
+var update = &UpdateRunner{}
+
+type UpdateRunner struct {
+	AddResources    []string
+	RemoveResources []string
+	AddEnvs         []string
+	RemoveEnvs      []string
+}
+
+func (r *UpdateRunner) Run(ctx context.Context, args []string) error {
+	ops := &api.AgentBulkOperation{}
+
+	if len(r.AddResources) > 0 || len(r.RemoveResources) > 0 {
+		ops.Resources = &api.AddRemove{Add: r.AddResources, Remove: r.RemoveResources}
+	}
+
+	if len(r.AddEnvs) > 0 || len(r.RemoveEnvs) > 0 {
+		ops.Environments = &api.AddRemove{Add: r.AddEnvs, Remove: r.RemoveEnvs}
+	}
+
+	if nil == ops.Resources && nil == ops.Environments {
+		return errors.New(`Nothing to update; use --add-resource, --remove-resource, --add-env, or --remove-env`)
+	}
+
+	agents, err := selectAgents(ctx, args)
+
+	if err != nil {
+		return err
+	}
+
+	if msg, err := api.DefaultClient.Agents.BulkUpdate(ctx, &api.AgentBulkUpdate{Uuids: api.AgentUuids(agents), Operations: ops}); err != nil {
+		return err
+	} else {
+		utils.Echofln(msg)
+	}
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(UpdateCmd)
+	UpdateCmd.Flags().StringArrayVar(&update.AddResources, "add-resource", nil, "resource to add; may be repeated")
+	UpdateCmd.Flags().StringArrayVar(&update.RemoveResources, "remove-resource", nil, "resource to remove; may be repeated")
+	UpdateCmd.Flags().StringArrayVar(&update.AddEnvs, "add-env", nil, "environment to add the agents to; may be repeated")
+	UpdateCmd.Flags().StringArrayVar(&update.RemoveEnvs, "remove-env", nil, "environment to remove the agents from; may be repeated")
+}
@@ -0,0 +1,85 @@
+package artifact
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var GetCmd = &cobra.Command{
+	Use:   "get <pipeline>/<counter>/<stage>[/<counter>]/<job> <path>",
+	Short: "Downloads an artifact file, or a folder of artifacts as a zip file",
+	Long: strings.Trim(`
+Downloads an artifact file, or a folder of artifacts as a zip file, into the
+current directory. Use -o to choose another directory or file name.`, "\n"),
+	Example: strings.Trim(`
+  gocd artifact get my-pipeline/latest/build/compile dist/app.jar
+  gocd artifact get my-pipeline/42/test/integration reports -o /tmp/reports.zip`, "\n"),
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return get.Run(cmd.Context(), args)
+	},
+}
+
+var get = &GetRunner{}
+
+type GetRunner struct {
+	Output string
+}
+
+func (r *GetRunner) Run(ctx context.Context, args []string) error {
+	loc, err := api.DefaultClient.LocateJob(ctx, args[0])
+
+	if err != nil {
+		return err
+	}
+
+	artifacts := api.DefaultClient.Artifacts
+	tree, err := artifacts.Tree(ctx, loc)
+
+	if err != nil {
+		return notFound(err, loc)
+	}
+
+	a := tree.Find(args[1])
+
+	if nil == a || a == tree {
+		return fmt.Errorf(`No such artifact in %s: %q`, loc, args[1])
+	}
+
+	name := a.Name
+	if a.IsFolder() {
+		name += `.zip`
+	}
+
+	dir, name := r.destination(name)
+
+	utils.Echofln(`Downloading %s from %s`, args[1], loc)
+	_, err = artifacts.Download(ctx, loc, args[1], a.IsFolder(), dir, name)
+	return err
+}
+
+// Resolves -o to a directory and file name; a directory keeps the artifact's
+// name
+func (r *GetRunner) destination(name string) (string, string) {
+	if "" == r.Output {
+		return `.`, name
+	}
+
+	if fi, err := os.Stat(r.Output); err == nil && fi.IsDir() {
+		return r.Output, name
+	}
+
+	return filepath.Dir(r.Output), filepath.Base(r.Output)
+}
+
+func init() {
+	RootCmd.AddCommand(GetCmd)
+	GetCmd.Flags().StringVarP(&get.Output, "output", "o", "", "the directory or file to download to")
+}
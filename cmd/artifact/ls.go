@@ -0,0 +1,83 @@
+package artifact
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var LsCmd = &cobra.Command{
+	Use:     "ls <pipeline>/<counter>/<stage>[/<counter>]/<job> [path]",
+	Short:   "Lists a job's artifacts, or those in a folder of them",
+	Example: `  gocd artifact ls my-pipeline/latest/build/compile dist`,
+	Args:    cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return ls.Run(cmd.Context(), args)
+	},
+}
+
+var ls = &LsRunner{}
+
+type LsRunner struct {
+	Recursive bool
+}
+
+func (r *LsRunner) Run(ctx context.Context, args []string) error {
+	loc, err := api.DefaultClient.LocateJob(ctx, args[0])
+
+	if err != nil {
+		return err
+	}
+
+	tree, err := api.DefaultClient.Artifacts.Tree(ctx, loc)
+
+	if err != nil {
+		return notFound(err, loc)
+	}
+
+	p := ``
+	if len(args) > 1 {
+		p = args[1]
+	}
+
+	a := tree.Find(p)
+
+	if nil == a {
+		return fmt.Errorf(`No such artifact in %s: %q`, loc, p)
+	}
+
+	if !a.IsFolder() {
+		utils.Echofln(a.Name)
+		return nil
+	}
+
+	printFolder(a, ``, r.Recursive)
+	return nil
+}
+
+// Lists the contents of a folder, suffixing subfolders with a slash
+func printFolder(folder *api.Artifact, prefix string, recursive bool) {
+	for _, f := range folder.Files {
+		name := path.Join(prefix, f.Name)
+
+		if !f.IsFolder() {
+			utils.Echofln(name)
+			continue
+		}
+
+		utils.Echofln(name + `/`)
+
+		if recursive {
+			printFolder(f, name, recursive)
+		}
+	}
+}
+
+func init() {
+	RootCmd.AddCommand(LsCmd)
+	LsCmd.Flags().BoolVarP(&ls.Recursive, "recursive", "R", false, "list the contents of subfolders too")
+}
@@ -0,0 +1,95 @@
+package artifact
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/dub"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var PutCmd = &cobra.Command{
+	Use:   "put <pipeline>/<counter>/<stage>[/<counter>]/<job> <dest-folder> <file>...",
+	Short: "Uploads files into a folder of a job's artifacts",
+	Long: strings.Trim(`
+Uploads files into a folder of a job's artifacts. Directories are uploaded with
+their contents, keeping their structure.`, "\n"),
+	Example: `  gocd artifact put my-pipeline/latest/test/integration reports build/reports/junit.xml`,
+	Args:    cobra.MinimumNArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return put.Run(cmd.Context(), args)
+	},
+}
+
+var put = &PutRunner{}
+
+type PutRunner struct{}
+
+func (r *PutRunner) Run(ctx context.Context, args []string) error {
+	loc, err := api.DefaultClient.LocateJob(ctx, args[0])
+
+	if err != nil {
+		return err
+	}
+
+	dest := args[1]
+
+	for _, f := range args[2:] {
+		if err := r.upload(ctx, loc, dest, f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Uploads a file into dest, or a directory into dest/<directory name>
+func (r *PutRunner) upload(ctx context.Context, loc *api.JobLocator, dest, file string) error {
+	fi, err := os.Stat(file)
+
+	if err != nil {
+		return err
+	}
+
+	if !fi.IsDir() {
+		return r.uploadFile(ctx, loc, dest, file)
+	}
+
+	base := filepath.Dir(filepath.Clean(file))
+
+	return filepath.WalkDir(file, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(base, filepath.Dir(p))
+
+		if err != nil {
+			return err
+		}
+
+		return r.uploadFile(ctx, loc, path.Join(dest, filepath.ToSlash(rel)), p)
+	})
+}
+
+func (r *PutRunner) uploadFile(ctx context.Context, loc *api.JobLocator, dest, file string) error {
+	body := dub.NewPipedMultipart()
+	body.AddFile(`file`, file)
+
+	if msg, err := api.DefaultClient.Artifacts.Upload(ctx, loc, dest, body); err != nil {
+		return notFound(err, loc)
+	} else {
+		utils.Echofln(msg)
+	}
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(PutCmd)
+}
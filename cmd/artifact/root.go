@@ -0,0 +1,20 @@
+package artifact
+
+import (
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/spf13/cobra"
+)
+
+// RootCmd represents the artifact command
+var RootCmd = &cobra.Command{
+	Use:   "artifact",
+	Short: "GoCD job artifact operations",
+	Long: `List, download, and upload the artifacts of a job. Jobs are located as
+pipeline/counter/stage[/counter]/job, where counters may be "latest"; an omitted
+stage counter also means the latest run of the stage.`,
+	ValidArgs: []string{"ls", "get", "put", "help"}, // bash-completion
+}
+
+func notFound(err error, loc *api.JobLocator) error {
+	return api.NotFound(err, `No such job run: %s`, loc)
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var HelperAuthCmd = &cobra.Command{
+	Use:   "auth-helper <command>",
+	Short: "Configures an external command that provides the auth token for API requests",
+	Long: strings.Trim(`
+This configures an external command, similar to git's credential helpers, which prints a
+Personal Access Token to STDOUT. The command is run through the shell whenever this CLI tool
+needs to authenticate, so the token itself is never written to the config file. The server
+url is passed to the command in the GOCD_SERVER_URL environment variable.`, "\n"),
+	Example: strings.Trim(`
+  gocd config auth-helper 'security find-generic-password -s gocd -w'   # reads the token from the macOS keychain
+  gocd config auth-helper 'secret-tool lookup service gocd'             # reads the token from the GNOME keyring
+  gocd config auth-helper 'pass show gocd/token'                        # reads the token from pass`, "\n"),
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		helperAuth.Run(args)
+	},
+}
+
+var helperAuth = &AuthHelperRunner{}
+
+type AuthHelperRunner struct{}
+
+func (ah *AuthHelperRunner) Run(args []string) {
+	if err := conf().SetHelperAuth(args[0]); err != nil {
+		utils.AbortLoudly(err)
+	}
+}
+
+func init() {
+	RootCmd.AddCommand(HelperAuthCmd)
+}
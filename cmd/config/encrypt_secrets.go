@@ -0,0 +1,47 @@
+package config
+
+import (
+	"os"
+	"strings"
+
+	"github.com/gocd-contrib/gocd-cli/cfg"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var EncryptSecretsCmd = &cobra.Command{
+	Use:   "encrypt-secrets",
+	Short: "Encrypts passwords and tokens stored in the config file",
+	Long: strings.Trim(`
+This encrypts all plaintext passwords and tokens in the config file, across all profiles, and
+ensures any credentials configured later are also encrypted. Secrets are encrypted with the
+passphrase in the GOCDCLI_PASSPHRASE environment variable if it is set; otherwise, a key file
+is created at $HOME/.gocd/secret.key, readable only by the current user.`, "\n"),
+	Example: strings.Trim(`
+  gocd config encrypt-secrets                                   # encrypts with the local key file
+  GOCDCLI_PASSPHRASE=hunter2 gocd config encrypt-secrets        # encrypts with a passphrase`, "\n"),
+	Args: cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		encryptSecrets.Run(args)
+	},
+}
+
+var encryptSecrets = &EncryptSecretsRunner{}
+
+type EncryptSecretsRunner struct{}
+
+func (es *EncryptSecretsRunner) Run(args []string) {
+	if count, err := conf().EncryptSecrets(); err != nil {
+		utils.AbortLoudly(err)
+	} else {
+		utils.Echofln(`Encrypted %d secret(s) in %s`, count, conf().ConfigFile())
+
+		if _, ok := os.LookupEnv(cfg.PASSPHRASE_ENV_VAR); ok {
+			utils.Echofln(`%s must be set for future commands to decrypt these secrets`, cfg.PASSPHRASE_ENV_VAR)
+		}
+	}
+}
+
+func init() {
+	RootCmd.AddCommand(EncryptSecretsCmd)
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"os"
+	"strings"
+
+	"github.com/gocd-contrib/gocd-cli/cfg"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var GetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Displays a single configured value; exits non-zero if the value is not set",
+	Long:  "Displays a single configured value for the active profile. Known keys: " + strings.Join(cfg.SettingKeys(), ", "),
+	Example: strings.Trim(`
+  gocd config get server.url                  # prints the server url
+  gocd config get auth.token --reveal         # prints the auth token in plain text
+  gocd config get auth.type -o json           # prints the value and its source as JSON`, "\n"),
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		getConfig.Run(args)
+	},
+}
+
+var getConfig = &GetConfigRunner{}
+
+type GetConfigRunner struct {
+	Output string
+	Reveal bool
+}
+
+func (gc *GetConfigRunner) Run(args []string) {
+	if err := validateOutput(gc.Output); err != nil {
+		utils.AbortLoudly(err)
+	}
+
+	s, err := conf().Lookup(args[0])
+
+	if err != nil {
+		utils.AbortLoudly(err)
+	}
+
+	s = reveal(s, gc.Reveal)
+
+	if `json` == gc.Output {
+		printJson(s)
+	} else if s.IsSet() {
+		utils.Echofln(s.Value)
+	}
+
+	if !s.IsSet() {
+		os.Exit(1)
+	}
+}
+
+func init() {
+	RootCmd.AddCommand(GetCmd)
+	GetCmd.Flags().StringVarP(&getConfig.Output, "output", "o", "text", "output format (text, json)")
+	GetCmd.Flags().BoolVar(&getConfig.Reveal, "reveal", false, "display secrets (passwords and tokens) in plain text")
+}
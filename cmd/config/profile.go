@@ -0,0 +1,16 @@
+package config
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var ProfileCmd = &cobra.Command{
+	Use:       "profile",
+	Short:     "Manages named server profiles",
+	Long:      "Profiles hold the server-url and auth settings for a GoCD server instance, so that one may switch between several servers without juggling config files.",
+	ValidArgs: []string{"add", "use", "list", "rm", "help"}, // bash-completion
+}
+
+func init() {
+	RootCmd.AddCommand(ProfileCmd)
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var ProfileAddCmd = &cobra.Command{
+	Use:   "add <name> <server-url>",
+	Short: "Creates a new profile pointing to a GoCD server instance",
+	Example: strings.Trim(`
+  gocd config profile add staging https://staging.example.com/go       # creates the "staging" profile
+  gocd --profile staging config auth-token abcd1234                   # configures auth for the "staging" profile`, "\n"),
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		profileAdd.Run(args)
+	},
+}
+
+var profileAdd = &ProfileAddRunner{}
+
+type ProfileAddRunner struct {
+	Use bool
+}
+
+func (pa *ProfileAddRunner) Run(args []string) {
+	if err := conf().AddProfile(args[0], args[1]); err != nil {
+		utils.AbortLoudly(err)
+	}
+
+	if pa.Use {
+		if err := conf().UseProfile(args[0]); err != nil {
+			utils.AbortLoudly(err)
+		}
+	}
+}
+
+func init() {
+	ProfileCmd.AddCommand(ProfileAddCmd)
+	ProfileAddCmd.Flags().BoolVar(&profileAdd.Use, "use", false, "also make this the current profile")
+}
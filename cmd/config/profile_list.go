@@ -0,0 +1,38 @@
+package config
+
+import (
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var ProfileListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "Lists the configured profiles; the active profile is marked with `*`",
+	Args:    cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		profileList.Run(args)
+	},
+}
+
+var profileList = &ProfileListRunner{}
+
+type ProfileListRunner struct{}
+
+func (pl *ProfileListRunner) Run(args []string) {
+	active := conf().ActiveProfile()
+
+	for _, name := range conf().Profiles() {
+		marker := ` `
+
+		if name == active {
+			marker = `*`
+		}
+
+		utils.Echofln(`%s %s`, marker, name)
+	}
+}
+
+func init() {
+	ProfileCmd.AddCommand(ProfileListCmd)
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var ProfileRmCmd = &cobra.Command{
+	Use:   "rm <name>",
+	Short: "Deletes a profile and all of its settings",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		profileRm.Run(args)
+	},
+}
+
+var profileRm = &ProfileRmRunner{}
+
+type ProfileRmRunner struct{}
+
+func (pr *ProfileRmRunner) Run(args []string) {
+	if err := conf().RemoveProfile(args[0]); err != nil {
+		utils.AbortLoudly(err)
+	}
+}
+
+func init() {
+	ProfileCmd.AddCommand(ProfileRmCmd)
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var ProfileUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Sets the current profile used by subsequent commands",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		profileUse.Run(args)
+	},
+}
+
+var profileUse = &ProfileUseRunner{}
+
+type ProfileUseRunner struct{}
+
+func (pu *ProfileUseRunner) Run(args []string) {
+	if err := conf().UseProfile(args[0]); err != nil {
+		utils.AbortLoudly(err)
+	}
+}
+
+func init() {
+	ProfileCmd.AddCommand(ProfileUseCmd)
+}
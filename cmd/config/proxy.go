@@ -0,0 +1,16 @@
+package config
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var ProxyCmd = &cobra.Command{
+	Use:       "proxy",
+	Short:     "Configures an HTTP proxy for API requests and plugin downloads",
+	Long:      "These settings apply to all profiles, and take precedence over the HTTP_PROXY, HTTPS_PROXY, and NO_PROXY environment variables. Use `gocd config rm proxy` to remove all of them.",
+	ValidArgs: []string{"http", "https", "no-proxy", "help"}, // bash-completion
+}
+
+func init() {
+	RootCmd.AddCommand(ProxyCmd)
+}
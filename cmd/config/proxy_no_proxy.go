@@ -0,0 +1,33 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var NoProxyCmd = &cobra.Command{
+	Use:   "no-proxy <hosts>",
+	Short: "Sets a comma-separated list of hosts, domains, IPs, and CIDR blocks that bypass the proxy",
+	Example: strings.Trim(`
+  gocd config proxy no-proxy gocd.corp.example.com,.internal,10.0.0.0/8`, "\n"),
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		noProxy.Run(args)
+	},
+}
+
+var noProxy = &NoProxyRunner{}
+
+type NoProxyRunner struct{}
+
+func (np *NoProxyRunner) Run(args []string) {
+	if err := conf().SetNoProxy(args[0]); err != nil {
+		utils.AbortLoudly(err)
+	}
+}
+
+func init() {
+	ProxyCmd.AddCommand(NoProxyCmd)
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var ProxyHttpCmd = &cobra.Command{
+	Use:   "http <proxy-url>",
+	Short: "Sets the proxy for plain HTTP requests",
+	Example: strings.Trim(`
+  gocd config proxy http http://proxy.corp:3128`, "\n"),
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		proxyHttp.Run(args)
+	},
+}
+
+var ProxyHttpsCmd = &cobra.Command{
+	Use:   "https <proxy-url>",
+	Short: "Sets the proxy for HTTPS requests",
+	Example: strings.Trim(`
+  gocd config proxy https http://proxy.corp:3128`, "\n"),
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		proxyHttps.Run(args)
+	},
+}
+
+var proxyHttp = &ProxyUrlRunner{Scheme: `http`}
+var proxyHttps = &ProxyUrlRunner{Scheme: `https`}
+
+type ProxyUrlRunner struct {
+	Scheme string
+}
+
+func (pr *ProxyUrlRunner) Run(args []string) {
+	if err := conf().SetProxy(pr.Scheme, args[0]); err != nil {
+		utils.AbortLoudly(err)
+	}
+}
+
+func init() {
+	ProxyCmd.AddCommand(ProxyHttpCmd)
+	ProxyCmd.AddCommand(ProxyHttpsCmd)
+}
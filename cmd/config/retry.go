@@ -0,0 +1,50 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gocd-contrib/gocd-cli/cfg"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var RetryCmd = &cobra.Command{
+	Use:   "retry <max-attempts>",
+	Short: "Configures how API requests are retried after network errors and 429, 502, 503, or 504 responses",
+	Long:  "Only idempotent requests whose bodies can be replayed are retried. Delays double after each attempt, unless the server sends a `Retry-After` header. Setting <max-attempts> to 1 disables retries; `--retries` overrides this for a single invocation.",
+	Example: strings.Trim(`
+  gocd config retry 5                                 # up to 4 retries with default delays
+  gocd config retry 3 --base-delay 2s --jitter 0.5    # waits ~2s, then ~4s, randomized by up to 50%
+  gocd config retry 1                                 # disables retries`, "\n"),
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		retry.Run(args)
+	},
+}
+
+var retry = &RetryRunner{}
+
+type RetryRunner struct {
+	BaseDelay time.Duration
+	Jitter    float64
+}
+
+func (rr *RetryRunner) Run(args []string) {
+	maxAttempts, err := strconv.Atoi(args[0])
+
+	if err != nil {
+		utils.DieLoudly(1, `Expected a number of attempts, but got %q`, args[0])
+	}
+
+	if err := conf().SetRetry(maxAttempts, rr.BaseDelay, rr.Jitter); err != nil {
+		utils.AbortLoudly(err)
+	}
+}
+
+func init() {
+	RootCmd.AddCommand(RetryCmd)
+	RetryCmd.Flags().DurationVar(&retry.BaseDelay, "base-delay", cfg.DEFAULT_RETRY_BASE_DELAY, "delay before the first retry; doubles with each subsequent retry")
+	RetryCmd.Flags().Float64Var(&retry.Jitter, "jitter", cfg.DEFAULT_RETRY_JITTER, "fraction (0.0 to 1.0) of each delay to randomize")
+}
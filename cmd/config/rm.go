@@ -12,7 +12,10 @@ var RmCmd = &cobra.Command{
 	Short: "Deletes a configured value",
 	Example: strings.Trim(`
   gocd config rm auth          # Deletes the current authentication configuration
-  gocd config rm server-url    # Deletes the server URL configuration`, "\n"),
+  gocd config rm server-url    # Deletes the server URL configuration
+  gocd config rm tls           # Deletes all TLS settings
+  gocd config rm proxy         # Deletes all proxy settings
+  gocd config rm retry         # Restores the default retry settings`, "\n"),
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		rmConfig.Run(args)
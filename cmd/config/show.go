@@ -0,0 +1,93 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gocd-contrib/gocd-cli/cfg"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var ShowCmd = &cobra.Command{
+	Use:     "show",
+	Aliases: []string{"list"},
+	Short:   "Displays the effective configuration for the active profile and where each value comes from",
+	Long:    "Displays the effective configuration for the active profile. Each value is annotated with its source: the config file, a GOCDCLI_* environment variable, a command-line flag, or the default. Secrets are masked unless --reveal is given.",
+	Example: strings.Trim(`
+  gocd config show                            # displays all configured values, masking secrets
+  gocd config show --output json              # machine-readable output`, "\n"),
+	Args: cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		showConfig.Run(args)
+	},
+}
+
+var showConfig = &ShowConfigRunner{}
+
+type ShowConfigRunner struct {
+	Output string
+	Reveal bool
+}
+
+func (sc *ShowConfigRunner) Run(args []string) {
+	if err := validateOutput(sc.Output); err != nil {
+		utils.AbortLoudly(err)
+	}
+
+	settings := make([]*cfg.Setting, 0)
+
+	for _, s := range conf().Settings() {
+		if s.IsSet() {
+			settings = append(settings, reveal(s, sc.Reveal))
+		}
+	}
+
+	if `json` == sc.Output {
+		byKey := make(map[string]*cfg.Setting, len(settings))
+		for _, s := range settings {
+			byKey[s.Key] = s
+		}
+
+		printJson(byKey)
+		return
+	}
+
+	w := tabwriter.NewWriter(utils.StdoutOrDevNull(), 0, 4, 2, ' ', 0)
+	for _, s := range settings {
+		fmt.Fprintf(w, "%s\t%s\t(%s)\n", s.Key, s.Value, s.Source)
+	}
+	w.Flush()
+}
+
+func reveal(s *cfg.Setting, unmask bool) *cfg.Setting {
+	if unmask {
+		return s
+	}
+	return s.Redacted()
+}
+
+func validateOutput(format string) error {
+	switch format {
+	case `text`, `json`:
+		return nil
+	default:
+		return fmt.Errorf(`Unknown output format %q; must be one of: text, json`, format)
+	}
+}
+
+func printJson(v interface{}) {
+	if b, err := json.MarshalIndent(v, ``, `  `); err != nil {
+		utils.AbortLoudly(err)
+	} else {
+		utils.Echofln(`%s`, string(b))
+	}
+}
+
+func init() {
+	RootCmd.AddCommand(ShowCmd)
+	ShowCmd.Flags().StringVarP(&showConfig.Output, "output", "o", "text", "output format (text, json)")
+	ShowCmd.Flags().BoolVar(&showConfig.Reveal, "reveal", false, "display secrets (passwords and tokens) in plain text")
+}
@@ -0,0 +1,16 @@
+package config
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var TlsCmd = &cobra.Command{
+	Use:       "tls",
+	Short:     "Configures TLS settings for connecting to the GoCD server instance",
+	Long:      "These settings belong to the current profile. Use `gocd config rm tls` to remove all of them.",
+	ValidArgs: []string{"ca-file", "client-cert", "insecure-skip-verify", "help"}, // bash-completion
+}
+
+func init() {
+	RootCmd.AddCommand(TlsCmd)
+}
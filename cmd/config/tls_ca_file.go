@@ -0,0 +1,33 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var TlsCaFileCmd = &cobra.Command{
+	Use:   "ca-file <path>",
+	Short: "Trusts the CA certificates in a PEM bundle, in addition to the system's trusted CAs",
+	Example: strings.Trim(`
+  gocd config tls ca-file /etc/pki/internal-ca.pem      # trusts certificates issued by an internal CA`, "\n"),
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tlsCaFile.Run(args)
+	},
+}
+
+var tlsCaFile = &TlsCaFileRunner{}
+
+type TlsCaFileRunner struct{}
+
+func (tr *TlsCaFileRunner) Run(args []string) {
+	if err := conf().SetTLSCAFile(args[0]); err != nil {
+		utils.AbortLoudly(err)
+	}
+}
+
+func init() {
+	TlsCmd.AddCommand(TlsCaFileCmd)
+}
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var TlsClientCertCmd = &cobra.Command{
+	Use:   "client-cert <cert-file> <key-file>",
+	Short: "Configures a PEM client certificate and private key for mutual TLS",
+	Example: strings.Trim(`
+  gocd config tls client-cert me.crt me.key             # presents me.crt to the server`, "\n"),
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		tlsClientCert.Run(args)
+	},
+}
+
+var tlsClientCert = &TlsClientCertRunner{}
+
+type TlsClientCertRunner struct{}
+
+func (tr *TlsClientCertRunner) Run(args []string) {
+	if err := conf().SetTLSClientCert(args[0], args[1]); err != nil {
+		utils.AbortLoudly(err)
+	}
+}
+
+func init() {
+	TlsCmd.AddCommand(TlsClientCertCmd)
+}
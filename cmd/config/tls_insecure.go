@@ -0,0 +1,43 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var TlsInsecureCmd = &cobra.Command{
+	Use:   "insecure-skip-verify <true|false>",
+	Short: "Disables (or re-enables) verification of the server's TLS certificate; NOT recommended",
+	Long:  "Disabling certificate verification exposes API credentials to man-in-the-middle attacks. Prefer `gocd config tls ca-file` whenever possible.",
+	Example: strings.Trim(`
+  gocd config tls insecure-skip-verify true             # accepts any server certificate
+  gocd config tls insecure-skip-verify false            # verifies server certificates again`, "\n"),
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"true", "false"},
+	Run: func(cmd *cobra.Command, args []string) {
+		tlsInsecure.Run(args)
+	},
+}
+
+var tlsInsecure = &TlsInsecureRunner{}
+
+type TlsInsecureRunner struct{}
+
+func (tr *TlsInsecureRunner) Run(args []string) {
+	insecure, err := strconv.ParseBool(args[0])
+
+	if err != nil {
+		utils.DieLoudly(1, `Expected "true" or "false", but got %q`, args[0])
+	}
+
+	if err := conf().SetTLSInsecureSkipVerify(insecure); err != nil {
+		utils.AbortLoudly(err)
+	}
+}
+
+func init() {
+	TlsCmd.AddCommand(TlsInsecureCmd)
+}
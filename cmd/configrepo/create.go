@@ -0,0 +1,93 @@
+package configrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var CreateCmd = &cobra.Command{
+	Use:   "create <id> --url <git-url>",
+	Short: "Creates a config-repo from a git repository",
+	Long:  "Creates a config-repo from a git repository, parsed by the config-repo plugin given by --plugin-id (or --json, --yaml, --groovy). Rules grant the config-repo access to entities it refers to, in the form `directive:action:type:resource` (e.g., `allow:refer:pipeline_group:team-*`).",
+	Example: strings.Trim(`
+  gocd configrepo --yaml create my-repo --url https://github.com/me/pipelines.git
+  gocd configrepo --yaml create my-repo --url https://github.com/me/pipelines.git --branch main \
+    --rule allow:refer:pipeline_group:team-* --rule allow:refer:environment:*`, "\n"),
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return create.Run(cmd.Context(), args)
+	},
+}
+
+var create = &CreateRunner{}
+
+type CreateRunner struct {
+	Url    string
+	Branch string
+	Rules  []string
+}
+
+func (r *CreateRunner) Run(ctx context.Context, args []string) error {
+	if err := requirePluginId(); err != nil {
+		return err
+	}
+
+	if "" == r.Url {
+		return errors.New(`You must provide a --url`)
+	}
+
+	attrs := map[string]interface{}{`url`: r.Url}
+
+	if "" != r.Branch {
+		attrs[`branch`] = r.Branch
+	}
+
+	repo := &api.ConfigRepo{
+		Id:       args[0],
+		PluginId: PluginId,
+		Material: api.Material{Type: `git`, Attributes: attrs},
+	}
+
+	for _, spec := range r.Rules {
+		if rule, err := parseRule(spec); err != nil {
+			return err
+		} else {
+			repo.Rules = append(repo.Rules, rule)
+		}
+	}
+
+	if _, err := api.DefaultClient.ConfigRepos.Create(ctx, repo); err != nil {
+		return err
+	}
+
+	utils.Echofln(`Created config-repo %q`, repo.Id)
+	return nil
+}
+
+// Parses a rule of the form `directive:action:type:resource`
+func parseRule(spec string) (api.Rule, error) {
+	parts := strings.SplitN(spec, `:`, 4)
+
+	if 4 != len(parts) {
+		return api.Rule{}, fmt.Errorf(`Invalid rule %q; expected directive:action:type:resource (e.g., allow:refer:pipeline_group:*)`, spec)
+	}
+
+	if `allow` != parts[0] && `deny` != parts[0] {
+		return api.Rule{}, fmt.Errorf(`Invalid rule %q; the directive must be "allow" or "deny"`, spec)
+	}
+
+	return api.Rule{Directive: parts[0], Action: parts[1], Type: parts[2], Resource: parts[3]}, nil
+}
+
+func init() {
+	RootCmd.AddCommand(CreateCmd)
+	CreateCmd.Flags().StringVar(&create.Url, "url", "", "URL of the git repository containing the definition files")
+	CreateCmd.Flags().StringVar(&create.Branch, "branch", "", "branch to track (defaults to the server's default, usually master)")
+	CreateCmd.Flags().StringArrayVar(&create.Rules, "rule", nil, "rule granting access to other entities, as directive:action:type:resource; may be repeated")
+}
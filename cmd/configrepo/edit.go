@@ -0,0 +1,103 @@
+package configrepo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var EditCmd = &cobra.Command{
+	Use:   "edit <id>",
+	Short: "Edits a config-repo's settings as JSON in $VISUAL or $EDITOR",
+	Long:  "Opens the config-repo's settings as JSON in $VISUAL or $EDITOR, and saves them when the editor exits. The update is rejected if the config-repo is modified concurrently; if saving fails, your edits are kept in a temporary file.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return edit.Run(cmd.Context(), args)
+	},
+}
+
+var edit = &EditRunner{}
+
+type EditRunner struct{}
+
+func (r *EditRunner) Run(ctx context.Context, args []string) error {
+	id := args[0]
+	repo, etag, err := api.DefaultClient.ConfigRepos.Get(ctx, id)
+
+	if err != nil {
+		return api.NotFound(err, `No such config-repo with id: %q`, id)
+	}
+
+	// read-only fields
+	repo.ParseInfo = nil
+	repo.MaterialUpdateInProgress = false
+
+	original, err := json.MarshalIndent(repo, ``, `  `)
+
+	if err != nil {
+		return utils.InspectError(err, `formatting config-repo %q`, id)
+	}
+
+	f, err := ioutil.TempFile(``, `gocd-configrepo-*.json`)
+
+	if err != nil {
+		return utils.InspectError(err, `creating temp file to edit config-repo %q`, id)
+	}
+
+	path := f.Name()
+	_, err = f.Write(append(original, '\n'))
+	f.Close()
+
+	if err != nil {
+		os.Remove(path)
+		return utils.InspectError(err, `writing temp file %q`, path)
+	}
+
+	if err := r.editAndSave(ctx, path, original, etag, id); err != nil {
+		utils.Errfln(`Your edits were kept in %s`, path)
+		return err
+	}
+
+	os.Remove(path)
+	return nil
+}
+
+func (r *EditRunner) editAndSave(ctx context.Context, path string, original []byte, etag, id string) error {
+	if err := utils.EditFile(path); err != nil {
+		return err
+	}
+
+	edited, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		return utils.InspectError(err, `reading edited file %q`, path)
+	}
+
+	if bytes.Equal(bytes.TrimSpace(original), bytes.TrimSpace(edited)) {
+		utils.Echofln(`No changes to config-repo %q`, id)
+		return nil
+	}
+
+	repo := &api.ConfigRepo{}
+
+	if err := json.Unmarshal(edited, repo); err != nil {
+		return fmt.Errorf(`Invalid JSON: %v`, err)
+	}
+
+	if id != repo.Id {
+		return fmt.Errorf(`The id of a config-repo cannot be changed (from %q to %q)`, id, repo.Id)
+	}
+
+	return saveRepo(ctx, repo, etag)
+}
+
+func init() {
+	RootCmd.AddCommand(EditCmd)
+}
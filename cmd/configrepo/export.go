@@ -1,12 +1,11 @@
 package configrepo
 
 import (
+	"context"
+	"fmt"
 	"io/ioutil"
-	"mime"
-	"net/url"
 
 	"github.com/gocd-contrib/gocd-cli/api"
-	"github.com/gocd-contrib/gocd-cli/dub"
 	"github.com/gocd-contrib/gocd-cli/utils"
 	"github.com/spf13/cobra"
 )
@@ -15,8 +14,8 @@ var ExportCmd = &cobra.Command{
 	Use:   "export <pipeline name>",
 	Short: "Exports the specified pipeline as a config-repo definition in the indicated config-repo plugin format",
 	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		export.Run(args)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return export.Run(cmd.Context(), args)
 	},
 }
 
@@ -24,48 +23,33 @@ var export = &ExportRunner{}
 
 type ExportRunner struct {
 	UseStdout bool
+
+	pipeline string
 }
 
-func (er *ExportRunner) Run(args []string) {
-	if "" == PluginId {
-		utils.DieLoudly(1, "You must provide a --plugin-id")
+func (er *ExportRunner) Run(ctx context.Context, args []string) error {
+	if err := requirePluginId(); err != nil {
+		return err
 	}
 
-	if err := api.V1.Get(er.url(args[0])).Send(er.onSuccess, er.onFail); err != nil {
-		utils.AbortLoudly(err)
-	}
-}
+	er.pipeline = args[0]
 
-func (er *ExportRunner) url(pipeline string) string {
-	return dub.AddQuery(`/api/admin/export/pipelines/`+url.PathEscape(pipeline), url.Values{
-		`plugin_id`: {PluginId},
-	})
-}
+	result, err := api.DefaultClient.Pipelines.Export(ctx, er.pipeline, PluginId)
 
-func (er *ExportRunner) onSuccess(res *dub.Response) error {
-	return api.ReadBodyAndDo(res, func(data []byte) error {
-		if er.UseStdout {
-			utils.Echofln(string(data))
-			return nil
-		}
+	if err != nil {
+		return api.NotFound(err, `No such pipeline: %q`, er.pipeline)
+	}
 
-		if _, params, err := mime.ParseMediaType(res.Headers.Get(`Content-Disposition`)); err == nil {
-			return ioutil.WriteFile(params[`filename`], data, 0644)
-		} else {
-			return err
-		}
-	})
-}
+	if er.UseStdout {
+		utils.Echofln(string(result.Content))
+		return nil
+	}
+
+	if "" == result.Filename {
+		return fmt.Errorf(`The server did not provide a filename for the exported pipeline %q; try --stdout`, er.pipeline)
+	}
 
-func (er *ExportRunner) onFail(res *dub.Response) error {
-	return api.ReadBodyAndDo(res, func(data []byte) error {
-		if m, err := api.ParseMessage(data); err == nil {
-			utils.Die(1, m.String()) // call Die() here instead of letting it hit AbortLoudly so it can be silenced
-			return nil
-		} else {
-			return err
-		}
-	})
+	return ioutil.WriteFile(result.Filename, result.Content, 0644)
 }
 
 func init() {
@@ -1,10 +1,13 @@
 package configrepo
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/cfg"
 	"github.com/gocd-contrib/gocd-cli/dub"
 	"github.com/gocd-contrib/gocd-cli/github"
 	"github.com/gocd-contrib/gocd-cli/plugins"
@@ -15,8 +18,8 @@ import (
 var FetchCmd = &cobra.Command{
 	Use:   "fetch",
 	Short: "Fetches configrepo plugins",
-	Run: func(cmd *cobra.Command, args []string) {
-		fetch.Run(args)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fetch.Run(cmd.Context(), args)
 	},
 }
 
@@ -27,20 +30,19 @@ type FetchRunner struct {
 	FilterBy   string
 }
 
-func (fr *FetchRunner) Run(args []string) {
-	if "" == PluginId {
-		utils.DieLoudly(1, "You must provide a --plugin-id")
+func (fr *FetchRunner) Run(ctx context.Context, args []string) error {
+	if err := requirePluginId(); err != nil {
+		return err
 	}
 
-	if _, err := fr.FetchPlugin(PluginId); err != nil {
-		utils.AbortLoudly(err)
-	}
+	_, err := fr.FetchPlugin(ctx, PluginId)
+	return err
 }
 
-func (fr *FetchRunner) FetchPlugin(id string) (string, error) {
+func (fr *FetchRunner) FetchPlugin(ctx context.Context, id string) (string, error) {
 	releases := make([]github.Release, 0)
 
-	if err := dub.New().Get(fr.releasesURL(PluginId)).Do(func(res *dub.Response) error {
+	if err := api.NewDownloadClient(cfg.Conf()).Get(fr.releasesURL(PluginId)).Do(ctx, func(res *dub.Response) error {
 		payload, err := res.ReadAll()
 
 		if err != nil {
@@ -76,7 +78,7 @@ func (fr *FetchRunner) FetchPlugin(id string) (string, error) {
 			}
 		}
 
-		return utils.Wget(a.Url, a.Name, PluginDir)
+		return utils.Wget(ctx, api.NewDownloadClient(cfg.Conf()), a.Url, a.Name, PluginDir)
 	}
 }
 
@@ -0,0 +1,46 @@
+package configrepo
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var ListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "Lists the config-repos on the GoCD server, with their material URL, plugin, and parse status",
+	Args:    cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return list.Run(cmd.Context(), args)
+	},
+}
+
+var list = &ListRunner{}
+
+type ListRunner struct{}
+
+func (r *ListRunner) Run(ctx context.Context, args []string) error {
+	repos, err := api.DefaultClient.ConfigRepos.List(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(utils.StdoutOrDevNull(), 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tPLUGIN\tURL\tSTATUS")
+
+	for _, repo := range repos {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", repo.Id, repo.PluginId, repo.Url(), repo.ParseStatus())
+	}
+
+	return w.Flush()
+}
+
+func init() {
+	RootCmd.AddCommand(ListCmd)
+}
@@ -1,9 +1,8 @@
 package configrepo
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
-	"net/url"
 
 	"github.com/gocd-contrib/gocd-cli/api"
 	"github.com/gocd-contrib/gocd-cli/dub"
@@ -15,8 +14,8 @@ var PreflightCmd = &cobra.Command{
 	Use:   "preflight <file> [<file2>, ...]",
 	Short: "Preflights any number of definition files for syntax, structure, and dependencies against a running GoCD server",
 	Args:  cobra.MinimumNArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		preflight.Run(args)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return preflight.Run(cmd.Context(), args)
 	},
 }
 
@@ -26,9 +25,9 @@ type PreflightRunner struct {
 	RepoId string
 }
 
-func (pr *PreflightRunner) Run(args []string) {
-	if "" == PluginId {
-		utils.DieLoudly(1, "You must provide a --plugin-id")
+func (pr *PreflightRunner) Run(ctx context.Context, args []string) error {
+	if err := requirePluginId(); err != nil {
+		return err
 	}
 
 	body := dub.NewPipedMultipart()
@@ -37,49 +36,21 @@ func (pr *PreflightRunner) Run(args []string) {
 		body.AddFile(`files[]`, f)
 	}
 
-	if err := api.V1.Post(pr.url(), body).Send(pr.onSuccess, pr.onFail); err != nil {
-		utils.AbortLoudly(err)
-	}
-}
-
-func (pr *PreflightRunner) onSuccess(res *dub.Response) error {
-	return api.ReadBodyAndDo(res, func(b []byte) error {
-		if result, err := ParseCrPreflight(b); err == nil {
-			if result.Valid {
-				utils.Echofln(`OK`)
-			} else {
-				utils.Die(1, result.DisplayErrors())
-			}
-		} else {
-			return utils.InspectError(err, `parsing preflight api response %q`, string(b))
-		}
-		return nil
-	})
-}
-
-func (pr *PreflightRunner) onFail(res *dub.Response) error {
-	return api.ReadBodyAndDo(res, func(b []byte) error {
-		api.DieOnAuthError(res)
-		api.DieOnNotFound(res, `No such config-repo with id: %q`, pr.RepoId)
+	result, err := api.DefaultClient.ConfigRepos.Preflight(ctx, &api.CrPreflightOpts{PluginId: PluginId, RepoId: pr.RepoId}, body)
 
-		if msg, err := api.ParseMessage(b); err == nil {
-			return fmt.Errorf(`Unexpected response %d: %s`, res.Status, msg)
-		} else {
-			return utils.InspectError(err, `parsing api error %d response: %q`, res.Status, string(b))
+	if err != nil {
+		if "" == pr.RepoId {
+			return err
 		}
-	})
-}
-
-func (pr *PreflightRunner) url() string {
-	query := url.Values{
-		`pluginId`: {PluginId},
+		return api.NotFound(err, `No such config-repo with id: %q`, pr.RepoId)
 	}
 
-	if "" != pr.RepoId {
-		query.Add(`repoId`, pr.RepoId)
+	if !result.Valid {
+		return &utils.QuietError{Message: result.DisplayErrors()}
 	}
 
-	return dub.AddQuery(`/api/admin/config_repo_ops/preflight`, query)
+	utils.Echofln(`OK`)
+	return nil
 }
 
 func ParseCrPreflight(body []byte) (*api.CrPreflightResponse, error) {
@@ -1,11 +1,9 @@
 package configrepo
 
 import (
-	"net/url"
-	"path"
+	"context"
 
 	"github.com/gocd-contrib/gocd-cli/api"
-	"github.com/gocd-contrib/gocd-cli/dub"
 	"github.com/gocd-contrib/gocd-cli/utils"
 	"github.com/spf13/cobra"
 )
@@ -14,8 +12,8 @@ var RmCmd = &cobra.Command{
 	Use:   "rm id",
 	Short: "Deletes a config-repo by id",
 	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		rm.Run(args)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return rm.Run(cmd.Context(), args)
 	},
 }
 
@@ -23,35 +21,13 @@ var rm = &RmRunner{}
 
 type RmRunner struct{}
 
-func (r *RmRunner) Run(args []string) {
-	if err := api.V1.Delete(r.url(args[0]), nil).Send(r.onSuccess, r.onFail); err != nil {
-		utils.AbortLoudly(err)
+func (r *RmRunner) Run(ctx context.Context, args []string) error {
+	if msg, err := api.DefaultClient.ConfigRepos.Delete(ctx, args[0]); err != nil {
+		return api.NotFound(err, `No such config-repo with id: %q`, args[0])
+	} else {
+		utils.Echofln(msg)
 	}
-}
-
-func (r *RmRunner) url(id string) string {
-	return path.Join(`/api/admin/config_repos`, url.PathEscape(id))
-}
-
-func (r *RmRunner) onSuccess(res *dub.Response) error {
-	return api.ReadBodyAndDo(res, func(b []byte) error {
-		if r, err := api.ParseMessage(b); err != nil {
-			return utils.InspectError(err, `parsing config-repo delete response: %s`, string(b))
-		} else {
-			utils.Echofln(r.String())
-		}
-		return nil
-	})
-}
-
-func (r *RmRunner) onFail(res *dub.Response) error {
-	return api.ReadBodyAndDo(res, func(b []byte) error {
-		_, id := path.Split(res.Raw.Request.URL.Path)
-		api.DieOnAuthError(res)
-		api.DieOnNotFound(res, `No such config-repo with id: %q`, id)
-
-		return nil
-	})
+	return nil
 }
 
 func init() {
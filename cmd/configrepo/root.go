@@ -1,6 +1,7 @@
 package configrepo
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 
@@ -19,7 +20,14 @@ var RootCmd = &cobra.Command{
 	Aliases:   []string{"cr"},
 	Short:     "GoCD config-repo functions",
 	Long:      `Functions to help development of config-repos in GoCD (pipeline configs as code)`,
-	ValidArgs: []string{"show", "rm", "syntax", "fetch", "preflight", "help"}, // bash-completion
+	ValidArgs: []string{"list", "show", "create", "edit", "update", "status", "trigger-update", "rm", "syntax", "fetch", "preflight", "export", "help"}, // bash-completion
+}
+
+func requirePluginId() error {
+	if "" == PluginId {
+		return errors.New(`You must provide a --plugin-id`)
+	}
+	return nil
 }
 
 func init() {
@@ -1,11 +1,10 @@
 package configrepo
 
 import (
-	"net/url"
-	"path"
+	"context"
+	"encoding/json"
 
 	"github.com/gocd-contrib/gocd-cli/api"
-	"github.com/gocd-contrib/gocd-cli/dub"
 	"github.com/gocd-contrib/gocd-cli/utils"
 	"github.com/spf13/cobra"
 )
@@ -14,8 +13,8 @@ var ShowCmd = &cobra.Command{
 	Use:   "show id",
 	Short: "Displays the settings for an existing config-repo",
 	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		show.Run(args)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return show.Run(cmd.Context(), args)
 	},
 }
 
@@ -23,31 +22,19 @@ var show = &ShowRunner{}
 
 type ShowRunner struct{}
 
-func (r *ShowRunner) Run(args []string) {
-	if err := api.V1.Get(r.url(args[0])).Send(r.onSuccess, r.onFail); err != nil {
-		utils.AbortLoudly(err)
-	}
-}
+func (r *ShowRunner) Run(ctx context.Context, args []string) error {
+	repo, _, err := api.DefaultClient.ConfigRepos.Get(ctx, args[0])
 
-func (r *ShowRunner) url(id string) string {
-	return path.Join(`/api/admin/config_repos`, url.PathEscape(id))
-}
+	if err != nil {
+		return api.NotFound(err, `No such config-repo with id: %q`, args[0])
+	}
 
-func (r *ShowRunner) onSuccess(res *dub.Response) error {
-	return api.ReadBodyAndDo(res, func(b []byte) error {
+	if b, err := json.MarshalIndent(repo, ``, `  `); err != nil {
+		return utils.InspectError(err, `formatting config-repo %q`, args[0])
+	} else {
 		utils.Echofln(string(b))
-		return nil
-	})
-}
-
-func (r *ShowRunner) onFail(res *dub.Response) error {
-	return api.ReadBodyAndDo(res, func(b []byte) error {
-		_, id := path.Split(res.Raw.Request.URL.Path)
-		api.DieOnAuthError(res)
-		api.DieOnNotFound(res, `No such config-repo with id: %q`, id)
-
-		return nil
-	})
+	}
+	return nil
 }
 
 func init() {
@@ -0,0 +1,70 @@
+package configrepo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var StatusCmd = &cobra.Command{
+	Use:   "status <id>",
+	Short: "Displays whether GoCD has parsed the latest revision of a config-repo, and any parse errors",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return status.Run(cmd.Context(), args)
+	},
+}
+
+var status = &StatusRunner{}
+
+type StatusRunner struct{}
+
+func (r *StatusRunner) Run(ctx context.Context, args []string) error {
+	repo, _, err := api.DefaultClient.ConfigRepos.Get(ctx, args[0])
+
+	if err != nil {
+		return api.NotFound(err, `No such config-repo with id: %q`, args[0])
+	}
+
+	printParseInfo(repo)
+	return nil
+}
+
+func printParseInfo(repo *api.ConfigRepo) {
+	utils.Echofln(`Config-repo:   %s`, repo.Id)
+	utils.Echofln(`Status:        %s`, repo.ParseStatus())
+
+	if info := repo.ParseInfo; nil != info {
+		utils.Echofln(`Last parsed:   %s`, describeModification(info.LatestParsedModification))
+		utils.Echofln(`Last good:     %s`, describeModification(info.GoodModification))
+
+		if errs := info.Errors(repo.Id); len(errs.Errors) > 0 {
+			utils.Echofln("\nParse errors:\n\n%s", errs.DisplayErrors())
+		}
+	}
+}
+
+func describeModification(m *api.Modification) string {
+	if nil == m {
+		return `(none)`
+	}
+
+	desc := m.Revision
+
+	if "" != m.Username {
+		desc += fmt.Sprintf(` by %s`, m.Username)
+	}
+
+	if "" != m.ModifiedTime {
+		desc += fmt.Sprintf(` at %s`, m.ModifiedTime)
+	}
+
+	return desc
+}
+
+func init() {
+	RootCmd.AddCommand(StatusCmd)
+}
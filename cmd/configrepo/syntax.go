@@ -1,6 +1,7 @@
 package configrepo
 
 import (
+	"context"
 	"encoding/json"
 	"os"
 	"os/exec"
@@ -16,8 +17,8 @@ var SyntaxCmd = &cobra.Command{
 	Use:   "syntax <file> [<file2>, ...]",
 	Short: "Checks one or more definition files for syntactical correctness",
 	Args:  cobra.MinimumNArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		syntax.Run(args)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return syntax.Run(cmd.Context(), args)
 	},
 }
 
@@ -27,15 +28,17 @@ type SyntaxRunner struct {
 	Raw bool
 }
 
-func (sr *SyntaxRunner) Run(args []string) {
-	if "" == PluginId {
-		utils.DieLoudly(1, "You must provide a --plugin-id")
+func (sr *SyntaxRunner) Run(ctx context.Context, args []string) error {
+	if err := requirePluginId(); err != nil {
+		return err
 	}
 
-	sr.FindOrDownloadPluginJar()
+	if err := sr.FindOrDownloadPluginJar(ctx); err != nil {
+		return err
+	}
 
 	cmdArgs := append([]string{"-jar", PluginJar, "syntax"}, args...)
-	cmd := exec.Command("java", cmdArgs...)
+	cmd := exec.CommandContext(ctx, "java", cmdArgs...)
 
 	var success bool
 
@@ -51,7 +54,7 @@ func (sr *SyntaxRunner) Run(args []string) {
 			resp := api.CrResponse{}
 
 			if err := json.Unmarshal([]byte(stderr.String()), &resp); err != nil {
-				utils.AbortLoudly(err)
+				return utils.InspectError(err, `parsing syntax check output %q`, stderr.String())
 			}
 
 			utils.Echofln(resp.DisplayErrors())
@@ -59,11 +62,13 @@ func (sr *SyntaxRunner) Run(args []string) {
 	}
 
 	if !success {
-		os.Exit(1)
+		return &utils.QuietError{} // errors have already been printed
 	}
+
+	return nil
 }
 
-func (sr *SyntaxRunner) FindOrDownloadPluginJar() {
+func (sr *SyntaxRunner) FindOrDownloadPluginJar(ctx context.Context) error {
 	var found string
 	var err error
 
@@ -71,17 +76,18 @@ func (sr *SyntaxRunner) FindOrDownloadPluginJar() {
 		utils.Errfln(`Could not find plugin %q in your plugin path.`, PluginId)
 
 		if _, err = fetch.GetReleaseUrl(PluginId); err != nil {
-			utils.AbortLoudly(err)
+			return err
 		} else {
 			utils.Echofln(`Attempting to download plugin %q...`, PluginId)
 		}
 
-		if found, err = fetch.FetchPlugin(PluginId); err != nil {
-			utils.AbortLoudly(err)
+		if found, err = fetch.FetchPlugin(ctx, PluginId); err != nil {
+			return err
 		}
 	}
 
 	PluginJar = found
+	return nil
 }
 
 func init() {
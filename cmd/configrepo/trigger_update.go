@@ -0,0 +1,116 @@
+package configrepo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+// How long to wait for the server to start an update before assuming it
+// finished too quickly to notice
+const updateStartGracePeriod = 10 * time.Second
+
+var TriggerUpdateCmd = &cobra.Command{
+	Use:   "trigger-update <id>",
+	Short: "Forces GoCD to check a config-repo's material for new revisions and parse them",
+	Long:  "Forces GoCD to check a config-repo's material for new revisions and parse them. With --wait, waits until parsing finishes, and exits non-zero if the config-repo has parse errors; combine with --timeout to bound the wait.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return triggerUpdate.Run(cmd.Context(), args)
+	},
+}
+
+var triggerUpdate = &TriggerUpdateRunner{}
+
+type TriggerUpdateRunner struct {
+	Wait         bool
+	PollInterval time.Duration
+}
+
+func (r *TriggerUpdateRunner) Run(ctx context.Context, args []string) error {
+	id := args[0]
+	repos := api.DefaultClient.ConfigRepos
+
+	var before *api.ConfigRepo
+
+	if r.Wait {
+		var err error
+		if before, _, err = repos.Get(ctx, id); err != nil {
+			return api.NotFound(err, `No such config-repo with id: %q`, id)
+		}
+	}
+
+	msg, err := repos.TriggerUpdate(ctx, id)
+
+	var apiErr *api.Error
+	switch {
+	case errors.As(err, &apiErr) && apiErr.IsConflict():
+		utils.Echofln(`An update of config-repo %q is already in progress`, id)
+	case err != nil:
+		return api.NotFound(err, `No such config-repo with id: %q`, id)
+	default:
+		utils.Echofln(msg)
+	}
+
+	if !r.Wait {
+		return nil
+	}
+
+	repo, err := r.waitForParse(ctx, id, lastParsedRevision(before))
+
+	if err != nil {
+		return err
+	}
+
+	printParseInfo(repo)
+
+	if `error` == repo.ParseStatus() {
+		return &utils.QuietError{}
+	}
+	return nil
+}
+
+// Waits until the update finishes, which is when the server no longer
+// reports it in progress, having either started it or parsed a new revision
+func (r *TriggerUpdateRunner) waitForParse(ctx context.Context, id, revisionBefore string) (*api.ConfigRepo, error) {
+	var repo *api.ConfigRepo
+	started, deadline := false, time.Now().Add(updateStartGracePeriod)
+
+	err := utils.Poll(ctx, r.PollInterval, func() (bool, error) {
+		s, err := api.DefaultClient.ConfigRepos.Status(ctx, id)
+
+		if err != nil {
+			return false, err
+		}
+
+		if s.InProgress {
+			started = true
+			return false, nil
+		}
+
+		if repo, _, err = api.DefaultClient.ConfigRepos.Get(ctx, id); err != nil {
+			return false, err
+		}
+
+		return started || revisionBefore != lastParsedRevision(repo) || time.Now().After(deadline), nil
+	})
+
+	return repo, err
+}
+
+func lastParsedRevision(repo *api.ConfigRepo) string {
+	if nil == repo || nil == repo.ParseInfo || nil == repo.ParseInfo.LatestParsedModification {
+		return ""
+	}
+	return repo.ParseInfo.LatestParsedModification.Revision
+}
+
+func init() {
+	RootCmd.AddCommand(TriggerUpdateCmd)
+	TriggerUpdateCmd.Flags().BoolVar(&triggerUpdate.Wait, "wait", false, "wait until parsing finishes; exits non-zero on parse errors")
+	TriggerUpdateCmd.Flags().DurationVar(&triggerUpdate.PollInterval, "poll-interval", 2*time.Second, "how often to check progress with --wait")
+}
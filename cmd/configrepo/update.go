@@ -0,0 +1,170 @@
+package configrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var UpdateCmd = &cobra.Command{
+	Use:   "update <id> --set key=value [--set key=value ...]",
+	Short: "Changes settings of an existing config-repo",
+	Long: strings.Trim(`
+Changes settings of an existing config-repo. Supported keys:
+
+  plugin_id             the config-repo plugin
+  url, branch           shorthand for material.url and material.branch
+  material.<attribute>  any attribute of the material (e.g., material.auto_update)
+  configuration.<key>   a plugin configuration property; an empty value removes it
+  rules                 comma-separated rules, as directive:action:type:resource;
+                        replaces all rules, and an empty value removes them
+
+The update is rejected if the config-repo is modified concurrently.`, "\n"),
+	Example: strings.Trim(`
+  gocd configrepo update my-repo --set branch=release
+  gocd configrepo update my-repo --set rules=allow:refer:pipeline_group:team-*,allow:refer:environment:*`, "\n"),
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return update.Run(cmd.Context(), args)
+	},
+}
+
+var update = &UpdateRunner{}
+
+type UpdateRunner struct {
+	Settings []string
+}
+
+func (r *UpdateRunner) Run(ctx context.Context, args []string) error {
+	id := args[0]
+
+	if 0 == len(r.Settings) {
+		return errors.New(`Nothing to update; specify at least one --set key=value`)
+	}
+
+	repo, etag, err := api.DefaultClient.ConfigRepos.Get(ctx, id)
+
+	if err != nil {
+		return api.NotFound(err, `No such config-repo with id: %q`, id)
+	}
+
+	for _, setting := range r.Settings {
+		kv := strings.SplitN(setting, `=`, 2)
+
+		if 2 != len(kv) {
+			return fmt.Errorf(`Invalid setting %q; expected key=value`, setting)
+		}
+
+		if err := applySetting(repo, kv[0], kv[1]); err != nil {
+			return err
+		}
+	}
+
+	return saveRepo(ctx, repo, etag)
+}
+
+func applySetting(repo *api.ConfigRepo, key, value string) error {
+	switch {
+	case `plugin_id` == key:
+		repo.PluginId = value
+	case `url` == key || `branch` == key:
+		return applySetting(repo, `material.`+key, value)
+	case strings.HasPrefix(key, `material.`) && len(key) > len(`material.`):
+		attr := strings.TrimPrefix(key, `material.`)
+
+		if nil == repo.Material.Attributes {
+			repo.Material.Attributes = make(map[string]interface{})
+		}
+
+		v, err := typedLike(repo.Material.Attributes[attr], value)
+
+		if err != nil {
+			return fmt.Errorf(`Invalid value for %s: %v`, key, err)
+		}
+
+		repo.Material.Attributes[attr] = v
+	case strings.HasPrefix(key, `configuration.`) && len(key) > len(`configuration.`):
+		setProperty(repo, strings.TrimPrefix(key, `configuration.`), value)
+	case `rules` == key:
+		repo.Rules = []api.Rule{}
+
+		for _, spec := range strings.Split(value, `,`) {
+			if "" == strings.TrimSpace(spec) {
+				continue
+			}
+
+			if rule, err := parseRule(strings.TrimSpace(spec)); err != nil {
+				return err
+			} else {
+				repo.Rules = append(repo.Rules, rule)
+			}
+		}
+	default:
+		return fmt.Errorf(`Unknown setting %q; see 'gocd configrepo update --help' for supported keys`, key)
+	}
+	return nil
+}
+
+// Parses value as the same JSON type as the current value, e.g., so that
+// `auto_update=false` remains a boolean
+func typedLike(current interface{}, value string) (interface{}, error) {
+	switch current.(type) {
+	case bool:
+		return strconv.ParseBool(value)
+	case float64:
+		return strconv.ParseFloat(value, 64)
+	default:
+		return value, nil
+	}
+}
+
+func setProperty(repo *api.ConfigRepo, key, value string) {
+	props := make([]api.ConfigProperty, 0, len(repo.Configuration)+1)
+	found := false
+
+	for _, p := range repo.Configuration {
+		if key == p.Key {
+			found = true
+
+			if "" == value {
+				continue
+			}
+
+			p = api.ConfigProperty{Key: key, Value: value, Secure: p.Secure}
+		}
+		props = append(props, p)
+	}
+
+	if !found && "" != value {
+		props = append(props, api.ConfigProperty{Key: key, Value: value})
+	}
+
+	repo.Configuration = props
+}
+
+// Saves the config-repo, provided that it has not changed on the server
+// since etag was fetched
+func saveRepo(ctx context.Context, repo *api.ConfigRepo, etag string) error {
+	// read-only fields
+	repo.ParseInfo = nil
+	repo.MaterialUpdateInProgress = false
+
+	if _, _, err := api.DefaultClient.ConfigRepos.Update(ctx, repo, etag); err != nil {
+		err = api.NotFound(err, `No such config-repo with id: %q`, repo.Id)
+		return api.Modified(err, `Config-repo %q was modified by someone else since it was fetched; run the command again to apply your changes to the latest version`, repo.Id)
+	}
+
+	utils.Echofln(`Updated config-repo %q`, repo.Id)
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(UpdateCmd)
+	UpdateCmd.Flags().StringArrayVar(&update.Settings, "set", nil, "setting to change, as key=value; may be repeated")
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+)
+
+var ErrInterrupted = errors.New(`Interrupted`)
+
+// The cause of a context canceled by `--timeout`
+type TimeoutError struct {
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf(`Timed out after %v (see --timeout)`, e.Timeout)
+}
+
+// Returns a context canceled with ErrInterrupted upon the first SIGINT.
+// Subsequent signals are not intercepted, so a second Ctrl-C terminates the
+// process immediately should cleanup hang.
+func interruptibleContext(parent context.Context) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(parent)
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt)
+
+	go func() {
+		select {
+		case <-sig:
+			signal.Stop(sig)
+			cancel(ErrInterrupted)
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, func() {
+		signal.Stop(sig)
+		cancel(nil)
+	}
+}
+
+// Applies the `--timeout` flag, if set, to the command's context
+func withTimeout(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return parent, func() {}
+	}
+
+	return context.WithTimeoutCause(parent, timeout, &TimeoutError{Timeout: timeout})
+}
@@ -0,0 +1,75 @@
+package environment
+
+import (
+	"context"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var CreateCmd = &cobra.Command{
+	Use:     "create <name>",
+	Short:   "Creates an environment",
+	Example: `  gocd environment create staging --pipeline deploy-web --env-var DEPLOY_ENV=staging --secure-env-var TOKEN=s3cr3t`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return create.Run(cmd.Context(), args)
+	},
+}
+
+var create = &CreateRunner{}
+
+type CreateRunner struct {
+	Pipelines     []string
+	EnvVars       []string
+	SecureEnvVars []string
+}
+
+func (r *CreateRunner) Run(ctx context.Context, args []string) error {
+	env := &api.Environment{Name: args[0], Pipelines: []api.NameRef{}, EnvironmentVariables: []api.EnvironmentVariable{}}
+
+	for _, p := range r.Pipelines {
+		env.AddPipeline(p)
+	}
+
+	if err := setVariables(env, r.EnvVars, r.SecureEnvVars); err != nil {
+		return err
+	}
+
+	if _, err := api.DefaultClient.Environments.Create(ctx, env); err != nil {
+		return err
+	}
+
+	utils.Echofln(`Created environment %q`, env.Name)
+	return nil
+}
+
+// Parses NAME=VALUE variables into the environment, replacing any of the
+// same name
+func setVariables(env *api.Environment, plain, secure []string) error {
+	for _, spec := range plain {
+		if v, err := api.ParseEnvironmentVariable(spec, false); err != nil {
+			return err
+		} else {
+			env.SetVariable(v)
+		}
+	}
+
+	for _, spec := range secure {
+		if v, err := api.ParseEnvironmentVariable(spec, true); err != nil {
+			return err
+		} else {
+			env.SetVariable(v)
+		}
+	}
+
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(CreateCmd)
+	CreateCmd.Flags().StringArrayVar(&create.Pipelines, "pipeline", nil, "pipeline to add to the environment; may be repeated")
+	CreateCmd.Flags().StringArrayVar(&create.EnvVars, "env-var", nil, "environment variable, as NAME=VALUE; may be repeated")
+	CreateCmd.Flags().StringArrayVar(&create.SecureEnvVars, "secure-env-var", nil, "secure environment variable, as NAME=VALUE; may be repeated")
+}
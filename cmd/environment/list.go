@@ -0,0 +1,53 @@
+package environment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var ListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "Lists environments, with their pipelines and agents",
+	Args:    cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return list.Run(cmd.Context(), args)
+	},
+}
+
+var list = &ListRunner{}
+
+type ListRunner struct{}
+
+func (r *ListRunner) Run(ctx context.Context, args []string) error {
+	envs, err := api.DefaultClient.Environments.List(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	agents, err := agentsByEnvironment(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(utils.StdoutOrDevNull(), 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tPIPELINES\tAGENTS")
+
+	for _, env := range envs {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", env.Name, strings.Join(api.Names(env.Pipelines), `,`), strings.Join(hostnames(agents[env.Name]), `,`))
+	}
+
+	return w.Flush()
+}
+
+func init() {
+	RootCmd.AddCommand(ListCmd)
+}
@@ -0,0 +1,146 @@
+package environment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var PatchCmd = &cobra.Command{
+	Use:   "patch <name>",
+	Short: "Adds or removes an environment's pipelines, agents, and environment variables",
+	Long: strings.Trim(`
+Adds or removes an environment's pipelines, agents, and environment variables.
+
+Pipelines and variables are changed by fetching the environment and saving the
+changed version, which fails if someone else has changed the environment in the
+meantime. Agents are selected by UUID, hostname, or hostname glob, and are
+changed through the agents API.`, "\n"),
+	Example: strings.Trim(`
+  gocd environment patch staging --add-pipeline deploy-api --remove-pipeline deploy-legacy
+  gocd environment patch staging --add-agent 'staging-*' --set-env-var LOG_LEVEL=debug`, "\n"),
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return patch.Run(cmd.Context(), args)
+	},
+}
+
+var patch = &PatchRunner{}
+
+type PatchRunner struct {
+	AddPipelines    []string
+	RemovePipelines []string
+	AddAgents       []string
+	RemoveAgents    []string
+	SetEnvVars      []string
+	SetSecureVars   []string
+	UnsetEnvVars    []string
+}
+
+func (r *PatchRunner) Run(ctx context.Context, args []string) error {
+	name := args[0]
+	changesEnv := len(r.AddPipelines)+len(r.RemovePipelines)+len(r.SetEnvVars)+len(r.SetSecureVars)+len(r.UnsetEnvVars) > 0
+	changesAgents := len(r.AddAgents)+len(r.RemoveAgents) > 0
+
+	if !changesEnv && !changesAgents {
+		return errors.New(`Nothing to change; use --add-pipeline, --remove-pipeline, --add-agent, --remove-agent, --set-env-var, --set-secure-env-var, or --unset-env-var`)
+	}
+
+	client := api.DefaultClient
+	env, etag, err := client.Environments.Get(ctx, name)
+
+	if err != nil {
+		return notFound(err, name)
+	}
+
+	if changesEnv {
+		if err := r.apply(env); err != nil {
+			return err
+		}
+
+		if _, _, err := client.Environments.Update(ctx, env, etag); err != nil {
+			err = notFound(err, name)
+			return api.Modified(err, `Environment %q was modified by someone else since it was fetched; run the command again to apply your changes to the latest version`, name)
+		}
+
+		utils.Echofln(`Updated environment %q`, name)
+	}
+
+	if changesAgents {
+		return r.updateAgents(ctx, name)
+	}
+
+	return nil
+}
+
+func (r *PatchRunner) apply(env *api.Environment) error {
+	for _, p := range r.AddPipelines {
+		env.AddPipeline(p)
+	}
+
+	for _, p := range r.RemovePipelines {
+		if !env.RemovePipeline(p) {
+			return fmt.Errorf(`Pipeline %q is not in environment %q`, p, env.Name)
+		}
+	}
+
+	for _, v := range r.UnsetEnvVars {
+		if !env.RemoveVariable(v) {
+			return fmt.Errorf(`Environment %q has no variable %q`, env.Name, v)
+		}
+	}
+
+	return setVariables(env, r.SetEnvVars, r.SetSecureVars)
+}
+
+func (r *PatchRunner) updateAgents(ctx context.Context, name string) error {
+	agents, err := api.DefaultClient.Agents.List(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	for _, change := range []struct {
+		patterns []string
+		op       *api.AddRemove
+	}{
+		{r.AddAgents, &api.AddRemove{Add: []string{name}}},
+		{r.RemoveAgents, &api.AddRemove{Remove: []string{name}}},
+	} {
+		if 0 == len(change.patterns) {
+			continue
+		}
+
+		matched, err := api.MatchAgents(agents, change.patterns)
+
+		if err != nil {
+			return err
+		}
+
+		update := &api.AgentBulkUpdate{Uuids: api.AgentUuids(matched), Operations: &api.AgentBulkOperation{Environments: change.op}}
+
+		if msg, err := api.DefaultClient.Agents.BulkUpdate(ctx, update); err != nil {
+			return err
+		} else {
+			utils.Echofln(msg)
+		}
+	}
+
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(PatchCmd)
+	PatchCmd.Flags().StringArrayVar(&patch.AddPipelines, "add-pipeline", nil, "pipeline to add; may be repeated")
+	PatchCmd.Flags().StringArrayVar(&patch.RemovePipelines, "remove-pipeline", nil, "pipeline to remove; may be repeated")
+	PatchCmd.Flags().StringArrayVar(&patch.AddAgents, "add-agent", nil, "agent UUID, hostname, or hostname glob to add; may be repeated")
+	PatchCmd.Flags().StringArrayVar(&patch.RemoveAgents, "remove-agent", nil, "agent UUID, hostname, or hostname glob to remove; may be repeated")
+	PatchCmd.Flags().StringArrayVar(&patch.SetEnvVars, "set-env-var", nil, "environment variable to add or replace, as NAME=VALUE; may be repeated")
+	PatchCmd.Flags().StringArrayVar(&patch.SetSecureVars, "set-secure-env-var", nil, "secure environment variable to add or replace, as NAME=VALUE; may be repeated")
+	PatchCmd.Flags().StringArrayVar(&patch.UnsetEnvVars, "unset-env-var", nil, "environment variable to remove; may be repeated")
+}
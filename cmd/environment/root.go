@@ -0,0 +1,56 @@
+package environment
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/spf13/cobra"
+)
+
+// RootCmd represents the environment command
+var RootCmd = &cobra.Command{
+	Use:       "environment",
+	Aliases:   []string{"env"},
+	Short:     "GoCD environment operations",
+	Long:      `List, inspect, create, change, and delete environments`,
+	ValidArgs: []string{"list", "show", "create", "patch", "delete", "help"}, // bash-completion
+}
+
+func notFound(err error, name string) error {
+	return api.NotFound(err, `No such environment: %q`, name)
+}
+
+// Returns the agents in each environment, keyed by environment name
+func agentsByEnvironment(ctx context.Context) (map[string][]*api.Agent, error) {
+	agents, err := api.DefaultClient.Agents.List(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	byEnv := make(map[string][]*api.Agent)
+
+	for _, a := range agents {
+		for _, name := range a.EnvironmentNames() {
+			byEnv[name] = append(byEnv[name], a)
+		}
+	}
+
+	return byEnv, nil
+}
+
+func hostnames(agents []*api.Agent) []string {
+	names := make([]string, 0, len(agents))
+	for _, a := range agents {
+		names = append(names, a.Hostname)
+	}
+	return names
+}
+
+func orNone(names []string) string {
+	if 0 == len(names) {
+		return `(none)`
+	}
+	return strings.Join(names, `, `)
+}
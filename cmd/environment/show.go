@@ -0,0 +1,74 @@
+package environment
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var ShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Displays an environment's pipelines, agents, and environment variables",
+	Long: strings.Trim(`
+Displays an environment's pipelines, agents, and environment variables. The
+values of secure variables are redacted unless --reveal is given; even then,
+the server only discloses their encrypted values.`, "\n"),
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return show.Run(cmd.Context(), args)
+	},
+}
+
+var show = &ShowRunner{}
+
+type ShowRunner struct {
+	Reveal bool
+}
+
+const redacted = `********`
+
+func (r *ShowRunner) Run(ctx context.Context, args []string) error {
+	env, _, err := api.DefaultClient.Environments.Get(ctx, args[0])
+
+	if err != nil {
+		return notFound(err, args[0])
+	}
+
+	agents, err := agentsByEnvironment(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	utils.Echofln(`Environment:   %s`, env.Name)
+	utils.Echofln(`Pipelines:     %s`, orNone(api.Names(env.Pipelines)))
+	utils.Echofln(`Agents:        %s`, orNone(hostnames(agents[env.Name])))
+
+	if 0 == len(env.EnvironmentVariables) {
+		utils.Echofln(`Variables:     (none)`)
+		return nil
+	}
+
+	utils.Echofln(`Variables:`)
+
+	for _, v := range env.EnvironmentVariables {
+		switch {
+		case !v.Secure:
+			utils.Echofln(`  %s=%s`, v.Name, v.Value)
+		case r.Reveal:
+			utils.Echofln(`  %s=%s (secure, encrypted)`, v.Name, v.EncryptedValue)
+		default:
+			utils.Echofln(`  %s=%s (secure)`, v.Name, redacted)
+		}
+	}
+
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(ShowCmd)
+	ShowCmd.Flags().BoolVar(&show.Reveal, "reveal", false, "show the encrypted values of secure variables instead of redacting them")
+}
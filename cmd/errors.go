@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+)
+
+// Exit codes; see README.md
+const (
+	EXIT_FAILURE       = 1
+	EXIT_AUTH          = 3
+	EXIT_NOT_FOUND     = 4
+	EXIT_CONFLICT      = 5
+	EXIT_UNPROCESSABLE = 6
+	EXIT_SERVER_ERROR  = 7
+	EXIT_TIMEOUT       = 124
+	EXIT_INTERRUPTED   = 130
+)
+
+// Implemented by errors that determine their own exit code
+type exitCoder interface {
+	ExitCode() int
+}
+
+func ExitCode(err error) int {
+	var apiErr *api.Error
+	var timeoutErr *TimeoutError
+	var coder exitCoder
+
+	switch {
+	case errors.Is(err, ErrInterrupted):
+		return EXIT_INTERRUPTED
+	case errors.As(err, &timeoutErr):
+		return EXIT_TIMEOUT
+	case errors.As(err, &apiErr):
+		switch {
+		case apiErr.IsAuthError():
+			return EXIT_AUTH
+		case apiErr.IsNotFound():
+			return EXIT_NOT_FOUND
+		case apiErr.IsConflict():
+			return EXIT_CONFLICT
+		case apiErr.IsUnprocessable():
+			return EXIT_UNPROCESSABLE
+		case apiErr.IsServerError():
+			return EXIT_SERVER_ERROR
+		}
+	case errors.As(err, &coder):
+		return coder.ExitCode()
+	}
+
+	return EXIT_FAILURE
+}
+
+// Prints the error and exits with the appropriate code. GoCD API errors are
+// expected failures, so they are silenced by `--quiet`; all other errors are
+// always printed.
+func exitWithError(err error) {
+	var apiErr *api.Error
+	var quiet *utils.QuietError
+
+	if errors.As(err, &apiErr) || errors.As(err, &quiet) {
+		if "" != err.Error() {
+			utils.Errfln(`%s`, err.Error())
+		}
+		os.Exit(ExitCode(err))
+	}
+
+	utils.DieLoudly(ExitCode(err), "%s", err.Error())
+}
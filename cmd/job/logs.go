@@ -0,0 +1,128 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var LogsCmd = &cobra.Command{
+	Use:   "logs <pipeline>/<counter>/<stage>[/<counter>]/<job>",
+	Short: "Prints a job's console log, optionally following it until the job completes",
+	Long: strings.Trim(`
+Prints a job's console log. With --follow, keeps printing new output while the
+job runs, and stops when it completes. Exits non-zero if the job has completed
+without passing.
+
+Use --since-line to skip the lines already seen, e.g., to resume following a log
+after an interruption.`, "\n"),
+	Example: strings.Trim(`
+  gocd job logs my-pipeline/latest/test/integration --follow
+  gocd job logs my-pipeline/42/test/1/integration --since-line 500`, "\n"),
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return logs.Run(cmd.Context(), args)
+	},
+}
+
+var logs = &LogsRunner{}
+
+type LogsRunner struct {
+	Follow       bool
+	SinceLine    int
+	PollInterval time.Duration
+}
+
+func (r *LogsRunner) Run(ctx context.Context, args []string) error {
+	if r.SinceLine < 0 {
+		return fmt.Errorf(`Invalid --since-line %d; must be 0 or more`, r.SinceLine)
+	}
+
+	loc, err := api.DefaultClient.LocateJob(ctx, args[0])
+
+	if err != nil {
+		return err
+	}
+
+	jobs := api.DefaultClient.Jobs
+	out := &lineSkipper{w: utils.StdoutOrDevNull(), skip: r.SinceLine}
+
+	var job *api.JobResult
+	var offset int64
+
+	if err := utils.Poll(ctx, r.PollInterval, func() (bool, error) {
+		var err error
+
+		// check the state first, so that the log is complete once the job is
+		if job, err = jobs.Instance(ctx, loc); err != nil {
+			return false, api.NotFound(err, `No such job run: %s`, loc)
+		}
+
+		if offset, err = jobs.ConsoleLog(ctx, loc, offset, out); err != nil {
+			var apiErr *api.Error
+
+			// the job has not started logging yet
+			if !errors.As(err, &apiErr) || !apiErr.IsNotFound() || job.IsCompleted() {
+				return false, api.NotFound(err, `No console log for job %s; it may have been purged`, loc)
+			}
+		}
+
+		return job.IsCompleted() || !r.Follow, nil
+	}); err != nil {
+		return err
+	}
+
+	if !job.IsCompleted() && 0 == offset && !r.Follow {
+		utils.Errfln(`Job %s has not started logging yet (%s)`, loc, job.State)
+	}
+
+	if job.IsCompleted() && `Passed` != job.Result {
+		return &utils.QuietError{Message: fmt.Sprintf(`Job %s: %s`, loc, job.Result)}
+	}
+
+	return nil
+}
+
+// Discards the first few lines written to it, passing the rest through to w
+type lineSkipper struct {
+	w    io.Writer
+	skip int
+}
+
+func (s *lineSkipper) Write(p []byte) (int, error) {
+	n := len(p)
+
+	for s.skip > 0 && len(p) > 0 {
+		i := bytes.IndexByte(p, '\n')
+
+		if i < 0 {
+			return n, nil
+		}
+
+		p = p[i+1:]
+		s.skip--
+	}
+
+	if len(p) > 0 {
+		if _, err := s.w.Write(p); err != nil {
+			return 0, err
+		}
+	}
+
+	return n, nil
+}
+
+func init() {
+	RootCmd.AddCommand(LogsCmd)
+	LogsCmd.Flags().BoolVarP(&logs.Follow, "follow", "f", false, "keep printing new output until the job completes")
+	LogsCmd.Flags().IntVar(&logs.SinceLine, "since-line", 0, "skip this many lines from the start of the log")
+	LogsCmd.Flags().DurationVar(&logs.PollInterval, "poll-interval", 2*time.Second, "how often to check for new output with --follow")
+}
@@ -0,0 +1,42 @@
+package job
+
+import (
+	"context"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var RerunCmd = &cobra.Command{
+	Use:     "rerun <pipeline>/<counter>/<stage>[/<counter>]/<job>",
+	Short:   "Reruns a single job of a stage",
+	Example: `  gocd job rerun my-pipeline/latest/test/integration`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return rerun.Run(cmd.Context(), args)
+	},
+}
+
+var rerun = &RerunRunner{}
+
+type RerunRunner struct{}
+
+func (r *RerunRunner) Run(ctx context.Context, args []string) error {
+	loc, err := api.DefaultClient.LocateJob(ctx, args[0])
+
+	if err != nil {
+		return err
+	}
+
+	if msg, err := api.DefaultClient.Stages.RunSelectedJobs(ctx, &loc.StageLocator, []string{loc.Job}); err != nil {
+		return api.NotFound(err, `No such job run: %s`, loc)
+	} else {
+		utils.Echofln(msg)
+	}
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(RerunCmd)
+}
@@ -0,0 +1,15 @@
+package job
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// RootCmd represents the job command
+var RootCmd = &cobra.Command{
+	Use:   "job",
+	Short: "GoCD job operations",
+	Long: `Rerun jobs, and print their console logs. Jobs are located as
+pipeline/counter/stage[/counter]/job, where counters may be "latest"; an omitted
+stage counter also means the latest run of the stage.`,
+	ValidArgs: []string{"rerun", "logs", "help"}, // bash-completion
+}
@@ -0,0 +1,200 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var HistoryCmd = &cobra.Command{
+	Use:   "history <name>",
+	Short: "Lists a pipeline's recent runs, with their triggers, material revisions, and stage results",
+	Long: strings.Trim(`
+Lists a pipeline's runs, most recent first. By default, shows the last 10 runs;
+use --limit to show more (0 shows all), and --since to show only the runs
+scheduled after a given date, e.g., 2024-01-31 or 2024-01-31T14:00 (local
+time), or an RFC 3339 timestamp.`, "\n"),
+	Example: strings.Trim(`
+  gocd pipeline history my-pipeline --limit 3
+  gocd pipeline history my-pipeline --since 2024-01-31 --limit 0`, "\n"),
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return history.Run(cmd.Context(), args)
+	},
+}
+
+var history = &HistoryRunner{}
+
+type HistoryRunner struct {
+	Limit int
+	Since string
+}
+
+// Bounds on the page size accepted by the pipeline history API
+const (
+	minPageSize = 10
+	maxPageSize = 100
+)
+
+func (r *HistoryRunner) Run(ctx context.Context, args []string) error {
+	name := args[0]
+
+	if r.Limit < 0 {
+		return fmt.Errorf(`Invalid --limit %d; must be 0 (no limit) or more`, r.Limit)
+	}
+
+	var since time.Time
+
+	if "" != r.Since {
+		var err error
+		if since, err = parseDate(r.Since); err != nil {
+			return err
+		}
+	}
+
+	pageSize := r.Limit
+	if 0 == pageSize || pageSize > maxPageSize {
+		pageSize = maxPageSize
+	} else if pageSize < minPageSize {
+		pageSize = minPageSize
+	}
+
+	pages := api.DefaultClient.Pipelines.HistoryPages(name, pageSize)
+	shown := 0
+
+	for pages.More() {
+		page := &api.PipelineHistory{}
+
+		if err := pages.Next(ctx, page); err != nil {
+			return notFound(err, name)
+		}
+
+		for _, run := range page.Pipelines {
+			// runs still being scheduled have no date yet, but are the newest
+			if !since.IsZero() && run.ScheduledDate > 0 && scheduledAt(run).Before(since) {
+				return summarize(name, shown)
+			}
+
+			printRun(run)
+			shown++
+
+			if shown == r.Limit {
+				return nil
+			}
+		}
+	}
+
+	return summarize(name, shown)
+}
+
+func summarize(name string, shown int) error {
+	if 0 == shown {
+		utils.Echofln(`No runs of pipeline %s to show`, name)
+	}
+	return nil
+}
+
+func printRun(run *api.PipelineInstance) {
+	when := `preparing to schedule`
+	if !run.PreparingToSchedule {
+		when = scheduledAt(run).Format(`2006-01-02 15:04:05`)
+	}
+
+	utils.Echofln(`%s/%d  %s  triggered by %s`, run.Name, run.Counter, when, triggeredBy(run.BuildCause))
+
+	for _, mr := range run.BuildCause.MaterialRevisions {
+		utils.Echofln(`  %s`, describeRevision(mr))
+	}
+
+	stages := make([]string, 0, len(run.Stages))
+
+	for _, s := range run.Stages {
+		stages = append(stages, fmt.Sprintf(`%s: %s`, s.Name, stageState(s)))
+	}
+
+	if len(stages) > 0 {
+		utils.Echofln(`  stages: %s`, strings.Join(stages, `, `))
+	}
+}
+
+func scheduledAt(run *api.PipelineInstance) time.Time {
+	return time.Unix(0, run.ScheduledDate*int64(time.Millisecond))
+}
+
+// GoCD reports material-triggered runs as approved by `changes`; name the
+// person who made the changes instead
+func triggeredBy(bc api.BuildCause) string {
+	if "" != bc.Approver && `changes` != bc.Approver {
+		return bc.Approver
+	}
+
+	for _, mr := range bc.MaterialRevisions {
+		if mr.Changed && len(mr.Modifications) > 0 && "" != mr.Modifications[0].UserName {
+			return `changes from ` + mr.Modifications[0].UserName
+		}
+	}
+
+	if "" != bc.Message {
+		return bc.Message
+	}
+
+	return `changes`
+}
+
+func describeRevision(mr api.MaterialRevision) string {
+	name := mr.Material.Name
+	if "" == name {
+		name = mr.Material.Description
+	}
+
+	desc := name + `: `
+
+	if len(mr.Modifications) > 0 {
+		desc += mr.Modifications[0].Revision
+	} else {
+		desc += `(unknown revision)`
+	}
+
+	if mr.Changed {
+		desc += ` (changed)`
+	}
+
+	return desc
+}
+
+func stageState(s *api.StageResult) string {
+	switch {
+	case !s.Scheduled:
+		return `not run`
+	case !s.IsCompleted() && "" != s.Status:
+		return s.Status
+	default:
+		return s.Result
+	}
+}
+
+// Parses a date or time in one of the formats accepted by --since
+func parseDate(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	for _, layout := range []string{`2006-01-02`, `2006-01-02T15:04`, `2006-01-02 15:04`, `2006-01-02T15:04:05`, `2006-01-02 15:04:05`} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf(`Invalid date %q; expected, e.g., 2024-01-31, 2024-01-31T14:00, or an RFC 3339 timestamp`, value)
+}
+
+func init() {
+	RootCmd.AddCommand(HistoryCmd)
+	HistoryCmd.Flags().IntVar(&history.Limit, "limit", 10, "maximum number of runs to show; 0 shows all")
+	HistoryCmd.Flags().StringVar(&history.Since, "since", "", "only show runs scheduled at or after this date")
+}
@@ -0,0 +1,38 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var PauseCmd = &cobra.Command{
+	Use:   "pause <name>",
+	Short: "Pauses a pipeline so that it is not triggered by new material revisions",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return pause.Run(cmd.Context(), args)
+	},
+}
+
+var pause = &PauseRunner{}
+
+type PauseRunner struct {
+	Reason string
+}
+
+func (r *PauseRunner) Run(ctx context.Context, args []string) error {
+	if msg, err := api.DefaultClient.Pipelines.Pause(ctx, args[0], r.Reason); err != nil {
+		return notFound(err, args[0])
+	} else {
+		utils.Echofln(msg)
+	}
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(PauseCmd)
+	PauseCmd.Flags().StringVar(&pause.Reason, "reason", "", "why the pipeline is paused; displayed to other users")
+}
@@ -0,0 +1,14 @@
+package pipeline
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// RootCmd represents the pipeline command
+var RootCmd = &cobra.Command{
+	Use:       "pipeline",
+	Aliases:   []string{"pl"},
+	Short:     "GoCD pipeline operations",
+	Long:      `Trigger, pause, unpause, and unlock pipelines, and display their status and run history`,
+	ValidArgs: []string{"trigger", "pause", "unpause", "unlock", "status", "history", "help"}, // bash-completion
+}
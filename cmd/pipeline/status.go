@@ -0,0 +1,64 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var StatusCmd = &cobra.Command{
+	Use:   "status <name>",
+	Short: "Displays whether a pipeline is paused, locked, and schedulable",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return status.Run(cmd.Context(), args)
+	},
+}
+
+var status = &StatusRunner{}
+
+type StatusRunner struct{}
+
+func (r *StatusRunner) Run(ctx context.Context, args []string) error {
+	s, err := api.DefaultClient.Pipelines.Status(ctx, args[0])
+
+	if err != nil {
+		return notFound(err, args[0])
+	}
+
+	utils.Echofln(`Pipeline:      %s`, args[0])
+
+	if s.Paused {
+		utils.Echofln(`Paused:        yes, by %s (%s)`, s.PausedBy, orNone(s.PausedCause))
+	} else {
+		utils.Echofln(`Paused:        no`)
+	}
+
+	utils.Echofln(`Locked:        %s`, yesNo(s.Locked))
+	utils.Echofln(`Schedulable:   %s`, yesNo(s.Schedulable))
+	return nil
+}
+
+func notFound(err error, name string) error {
+	return api.NotFound(err, `No such pipeline: %q`, name)
+}
+
+func yesNo(b bool) string {
+	if b {
+		return `yes`
+	}
+	return `no`
+}
+
+func orNone(s string) string {
+	if "" == s {
+		return `no reason given`
+	}
+	return s
+}
+
+func init() {
+	RootCmd.AddCommand(StatusCmd)
+}
@@ -0,0 +1,223 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var TriggerCmd = &cobra.Command{
+	Use:   "trigger <name>",
+	Short: "Triggers a pipeline run",
+	Long: strings.Trim(`
+Triggers a pipeline run, optionally overriding environment variables and pinning
+materials to specific revisions. Materials are identified by name, URL, or
+fingerprint, as in --material my-repo=1a2b3c; if the pipeline has a single
+material, --material 1a2b3c suffices.
+
+With --wait, follows the resulting run until it finishes (or reaches a stage
+awaiting manual approval), and exits non-zero unless it passes.`, "\n"),
+	Example: strings.Trim(`
+  gocd pipeline trigger my-pipeline
+  gocd pipeline trigger my-pipeline --env-var DEPLOY_ENV=staging --material my-repo=1a2b3c --wait`, "\n"),
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return trigger.Run(cmd.Context(), args)
+	},
+}
+
+var trigger = &TriggerRunner{}
+
+type TriggerRunner struct {
+	EnvVars       []string
+	SecureEnvVars []string
+	Materials     []string
+	Wait          bool
+	PollInterval  time.Duration
+}
+
+func (r *TriggerRunner) Run(ctx context.Context, args []string) error {
+	name := args[0]
+	pipelines := api.DefaultClient.Pipelines
+	opts := &api.ScheduleOptions{}
+
+	for _, spec := range r.EnvVars {
+		if v, err := api.ParseEnvironmentVariable(spec, false); err != nil {
+			return err
+		} else {
+			opts.EnvironmentVariables = append(opts.EnvironmentVariables, v)
+		}
+	}
+
+	for _, spec := range r.SecureEnvVars {
+		if v, err := api.ParseEnvironmentVariable(spec, true); err != nil {
+			return err
+		} else {
+			opts.EnvironmentVariables = append(opts.EnvironmentVariables, v)
+		}
+	}
+
+	var latest *api.PipelineInstance
+
+	if r.Wait || len(r.Materials) > 0 {
+		history, err := pipelines.History(ctx, name)
+
+		if err != nil {
+			return notFound(err, name)
+		}
+
+		if len(history.Pipelines) > 0 {
+			latest = history.Pipelines[0]
+		}
+	}
+
+	for _, spec := range r.Materials {
+		if m, err := resolveMaterial(name, spec, latest); err != nil {
+			return err
+		} else {
+			opts.Materials = append(opts.Materials, m)
+		}
+	}
+
+	if msg, err := pipelines.Schedule(ctx, name, opts); err != nil {
+		return notFound(err, name)
+	} else {
+		utils.Echofln(msg)
+	}
+
+	if !r.Wait {
+		return nil
+	}
+
+	previous := 0
+	if nil != latest {
+		previous = latest.Counter
+	}
+
+	return r.follow(ctx, name, previous)
+}
+
+// Waits for a run newer than the previous one to start, then follows it to
+// completion, printing each stage's progress
+func (r *TriggerRunner) follow(ctx context.Context, name string, previous int) error {
+	pipelines := api.DefaultClient.Pipelines
+	var run *api.PipelineInstance
+
+	if err := utils.Poll(ctx, r.PollInterval, func() (bool, error) {
+		history, err := pipelines.History(ctx, name)
+
+		if err != nil {
+			return false, err
+		}
+
+		if len(history.Pipelines) > 0 && history.Pipelines[0].Counter > previous {
+			run = history.Pipelines[0]
+			return true, nil
+		}
+		return false, nil
+	}); err != nil {
+		return err
+	}
+
+	utils.Echofln(`Following %s/%d`, name, run.Counter)
+
+	printed := make(map[string]string)
+	var result string
+
+	if err := utils.Poll(ctx, r.PollInterval, func() (bool, error) {
+		var err error
+
+		if run, err = pipelines.Instance(ctx, name, run.Counter); err != nil {
+			return false, err
+		}
+
+		for _, s := range run.Stages {
+			if !s.Scheduled {
+				continue
+			}
+
+			state := s.Status
+			if "" == state {
+				state = s.Result
+			}
+
+			if printed[s.Name] != state {
+				utils.Echofln(`  %s: %s`, s.Name, state)
+				printed[s.Name] = state
+			}
+		}
+
+		var done bool
+		done, result = run.Outcome()
+		return done, nil
+	}); err != nil {
+		return err
+	}
+
+	if `Passed` != result {
+		return &utils.QuietError{Message: fmt.Sprintf(`Pipeline %s/%d: %s`, name, run.Counter, result)}
+	}
+
+	utils.Echofln(`Pipeline %s/%d: %s`, name, run.Counter, result)
+	return nil
+}
+
+// Resolves a `[material=]revision` spec to the material's fingerprint, using
+// the materials of the pipeline's latest run
+func resolveMaterial(pipeline, spec string, latest *api.PipelineInstance) (api.ScheduleMaterial, error) {
+	material, revision := ``, spec
+
+	if i := strings.LastIndex(spec, `=`); i >= 0 {
+		material, revision = spec[:i], spec[i+1:]
+	}
+
+	if "" == revision {
+		return api.ScheduleMaterial{}, fmt.Errorf(`Invalid material %q; expected [material=]revision`, spec)
+	}
+
+	if nil == latest {
+		if "" == material {
+			return api.ScheduleMaterial{}, fmt.Errorf(`Cannot tell which material to pin, as pipeline %q has never run; use --material <fingerprint>=<revision>`, pipeline)
+		}
+
+		// assume a fingerprint, as there is nothing to match names against
+		return api.ScheduleMaterial{Fingerprint: material, Revision: revision}, nil
+	}
+
+	revisions := latest.BuildCause.MaterialRevisions
+	names := make([]string, 0, len(revisions))
+
+	for _, mr := range revisions {
+		m := mr.Material
+
+		if ("" == material && 1 == len(revisions)) || (material != "" && (material == m.Name || material == m.Fingerprint || material == m.Description)) {
+			return api.ScheduleMaterial{Fingerprint: m.Fingerprint, Revision: revision}, nil
+		}
+
+		if "" != m.Name {
+			names = append(names, m.Name)
+		} else {
+			names = append(names, m.Description)
+		}
+	}
+
+	if "" == material {
+		return api.ScheduleMaterial{}, fmt.Errorf(`Pipeline %q has several materials; specify one of: %s (e.g., --material %s=%s)`, pipeline, strings.Join(names, `, `), names[0], revision)
+	}
+
+	return api.ScheduleMaterial{}, fmt.Errorf(`Pipeline %q has no material %q; expected one of: %s`, pipeline, material, strings.Join(names, `, `))
+}
+
+func init() {
+	RootCmd.AddCommand(TriggerCmd)
+	TriggerCmd.Flags().StringArrayVar(&trigger.EnvVars, "env-var", nil, "environment variable override, as NAME=VALUE; may be repeated")
+	TriggerCmd.Flags().StringArrayVar(&trigger.SecureEnvVars, "secure-env-var", nil, "secure environment variable override, as NAME=VALUE; may be repeated")
+	TriggerCmd.Flags().StringArrayVar(&trigger.Materials, "material", nil, "material revision to run with, as [material=]revision; may be repeated")
+	TriggerCmd.Flags().BoolVar(&trigger.Wait, "wait", false, "follow the run until it finishes; exits non-zero unless it passes")
+	TriggerCmd.Flags().DurationVar(&trigger.PollInterval, "poll-interval", 5*time.Second, "how often to check progress with --wait")
+}
@@ -0,0 +1,35 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var UnlockCmd = &cobra.Command{
+	Use:   "unlock <name>",
+	Short: "Releases the lock on a pipeline that runs one instance at a time, e.g., after a failed run",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return unlock.Run(cmd.Context(), args)
+	},
+}
+
+var unlock = &UnlockRunner{}
+
+type UnlockRunner struct{}
+
+func (r *UnlockRunner) Run(ctx context.Context, args []string) error {
+	if msg, err := api.DefaultClient.Pipelines.Unlock(ctx, args[0]); err != nil {
+		return notFound(err, args[0])
+	} else {
+		utils.Echofln(msg)
+	}
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(UnlockCmd)
+}
@@ -0,0 +1,35 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var UnpauseCmd = &cobra.Command{
+	Use:   "unpause <name>",
+	Short: "Unpauses a paused pipeline",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return unpause.Run(cmd.Context(), args)
+	},
+}
+
+var unpause = &UnpauseRunner{}
+
+type UnpauseRunner struct{}
+
+func (r *UnpauseRunner) Run(ctx context.Context, args []string) error {
+	if msg, err := api.DefaultClient.Pipelines.Unpause(ctx, args[0]); err != nil {
+		return notFound(err, args[0])
+	} else {
+		utils.Echofln(msg)
+	}
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(UnpauseCmd)
+}
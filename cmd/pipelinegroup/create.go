@@ -0,0 +1,35 @@
+package pipelinegroup
+
+import (
+	"context"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var CreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Creates an empty pipeline group",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return create.Run(cmd.Context(), args)
+	},
+}
+
+var create = &CreateRunner{}
+
+type CreateRunner struct{}
+
+func (r *CreateRunner) Run(ctx context.Context, args []string) error {
+	if _, err := api.DefaultClient.PipelineGroups.Create(ctx, &api.PipelineGroup{Name: args[0]}); err != nil {
+		return err
+	}
+
+	utils.Echofln(`Created pipeline group %q`, args[0])
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(CreateCmd)
+}
@@ -0,0 +1,36 @@
+package pipelinegroup
+
+import (
+	"context"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var DeleteCmd = &cobra.Command{
+	Use:     "delete <name>",
+	Aliases: []string{"rm"},
+	Short:   "Deletes a pipeline group; only empty groups can be deleted",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return del.Run(cmd.Context(), args)
+	},
+}
+
+var del = &DeleteRunner{}
+
+type DeleteRunner struct{}
+
+func (r *DeleteRunner) Run(ctx context.Context, args []string) error {
+	if msg, err := api.DefaultClient.PipelineGroups.Delete(ctx, args[0]); err != nil {
+		return notFound(err, args[0])
+	} else {
+		utils.Echofln(msg)
+	}
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(DeleteCmd)
+}
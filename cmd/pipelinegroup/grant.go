@@ -0,0 +1,81 @@
+package pipelinegroup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var GrantCmd = &cobra.Command{
+	Use:   "grant <group> (--user <name> | --role <name>)... (--view | --operate | --admin)...",
+	Short: "Grants users and roles permissions on a pipeline group",
+	Long: strings.Trim(`
+Grants users and roles permissions on a pipeline group. The group is saved against
+the version fetched, so that concurrent changes are not overwritten.`, "\n"),
+	Example: `  gocd pipeline-group grant payments --role payments-devs --view --operate`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return grant.Run(cmd.Context(), args)
+	},
+}
+
+var grant = &GrantRunner{}
+
+type GrantRunner struct {
+	permissionFlags
+}
+
+func (r *GrantRunner) Run(ctx context.Context, args []string) error {
+	return changePermissions(ctx, args[0], &r.permissionFlags, false, (*api.PipelineGroup).Grant)
+}
+
+// Fetches the group, applies change to each selected permission, and saves
+// the group against the fetched version. Unless force is set, refuses to
+// remove the group's last grantee, which would open it to every user.
+func changePermissions(ctx context.Context, name string, flags *permissionFlags, force bool, change func(*api.PipelineGroup, string, []string, []string) error) error {
+	selected, err := flags.permissions()
+
+	if err != nil {
+		return err
+	}
+
+	groups := api.DefaultClient.PipelineGroups
+	group, etag, err := groups.Get(ctx, name)
+
+	if err != nil {
+		return notFound(err, name)
+	}
+
+	restricted := group.IsRestricted()
+
+	for _, p := range selected {
+		if err := change(group, p, flags.Users, flags.Roles); err != nil {
+			return err
+		}
+	}
+
+	if restricted && !group.IsRestricted() && !force {
+		return fmt.Errorf(`Refusing to remove the last permissions on pipeline group %q, as GoCD would then open it to every user; use --force to do so anyway`, name)
+	}
+
+	// pipelines are managed through the pipeline config, not the group; the
+	// update only concerns the group's authorization
+	group.Pipelines = nil
+
+	if _, _, err := groups.Update(ctx, group, etag); err != nil {
+		err = notFound(err, name)
+		return api.Modified(err, `Pipeline group %q was modified by someone else since it was fetched; run the command again to apply your changes to the latest version`, name)
+	}
+
+	utils.Echofln(`Updated pipeline group %q`, name)
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(GrantCmd)
+	grant.register(GrantCmd, `grant`)
+}
@@ -0,0 +1,46 @@
+package pipelinegroup
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var ListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "Lists pipeline groups, with their number of pipelines and who may view, operate, and administer them",
+	Args:    cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return list.Run(cmd.Context(), args)
+	},
+}
+
+var list = &ListRunner{}
+
+type ListRunner struct{}
+
+func (r *ListRunner) Run(ctx context.Context, args []string) error {
+	groups, err := api.DefaultClient.PipelineGroups.List(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(utils.StdoutOrDevNull(), 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tPIPELINES\tVIEW\tOPERATE\tADMIN")
+
+	for _, g := range groups {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", g.Name, len(g.Pipelines), describeGrantees(g, api.PermissionView), describeGrantees(g, api.PermissionOperate), describeGrantees(g, api.PermissionAdmin))
+	}
+
+	return w.Flush()
+}
+
+func init() {
+	RootCmd.AddCommand(ListCmd)
+}
@@ -0,0 +1,35 @@
+package pipelinegroup
+
+import (
+	"context"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/spf13/cobra"
+)
+
+var RevokeCmd = &cobra.Command{
+	Use:     "revoke <group> (--user <name> | --role <name>)... (--view | --operate | --admin)...",
+	Short:   "Revokes users' and roles' permissions on a pipeline group",
+	Example: `  gocd pipeline-group revoke payments --user alice --admin`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return revoke.Run(cmd.Context(), args)
+	},
+}
+
+var revoke = &RevokeRunner{}
+
+type RevokeRunner struct {
+	permissionFlags
+	Force bool
+}
+
+func (r *RevokeRunner) Run(ctx context.Context, args []string) error {
+	return changePermissions(ctx, args[0], &r.permissionFlags, r.Force, (*api.PipelineGroup).Revoke)
+}
+
+func init() {
+	RootCmd.AddCommand(RevokeCmd)
+	revoke.register(RevokeCmd, `revoke`)
+	RevokeCmd.Flags().BoolVar(&revoke.Force, "force", false, "revoke even the last permissions, opening the group to every user")
+}
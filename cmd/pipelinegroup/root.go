@@ -0,0 +1,73 @@
+package pipelinegroup
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/spf13/cobra"
+)
+
+// RootCmd represents the pipeline-group command
+var RootCmd = &cobra.Command{
+	Use:       "pipeline-group",
+	Aliases:   []string{"pg"},
+	Short:     "GoCD pipeline group operations",
+	Long:      `List, inspect, create, and delete pipeline groups, and manage who may view, operate, and administer them`,
+	ValidArgs: []string{"list", "show", "create", "delete", "grant", "revoke", "help"}, // bash-completion
+}
+
+func notFound(err error, name string) error {
+	return api.NotFound(err, `No such pipeline group: %q`, name)
+}
+
+// Summarizes the grantees of a permission, e.g., `alice, role:developers`
+func describeGrantees(group *api.PipelineGroup, permission string) string {
+	users, roles := group.Grantees(permission)
+	names := append([]string{}, users...)
+
+	for _, r := range roles {
+		names = append(names, `role:`+r)
+	}
+
+	return strings.Join(names, `, `)
+}
+
+var permissions = []string{api.PermissionView, api.PermissionOperate, api.PermissionAdmin}
+
+// The users, roles, and permissions to grant or revoke
+type permissionFlags struct {
+	Users   []string
+	Roles   []string
+	View    bool
+	Operate bool
+	Admin   bool
+}
+
+func (f *permissionFlags) register(cmd *cobra.Command, verb string) {
+	cmd.Flags().StringArrayVar(&f.Users, "user", nil, "user to "+verb+" the permissions; may be repeated")
+	cmd.Flags().StringArrayVar(&f.Roles, "role", nil, "role to "+verb+" the permissions; may be repeated")
+	cmd.Flags().BoolVar(&f.View, "view", false, "the permission to view the group's pipelines")
+	cmd.Flags().BoolVar(&f.Operate, "operate", false, "the permission to trigger, pause, and otherwise operate the group's pipelines")
+	cmd.Flags().BoolVar(&f.Admin, "admin", false, "the permission to administer the group and its pipelines")
+}
+
+func (f *permissionFlags) permissions() ([]string, error) {
+	if 0 == len(f.Users) && 0 == len(f.Roles) {
+		return nil, errors.New(`Specify at least one --user or --role`)
+	}
+
+	var selected []string
+
+	for i, on := range []bool{f.View, f.Operate, f.Admin} {
+		if on {
+			selected = append(selected, permissions[i])
+		}
+	}
+
+	if 0 == len(selected) {
+		return nil, errors.New(`Specify at least one of --view, --operate, or --admin`)
+	}
+
+	return selected, nil
+}
@@ -0,0 +1,49 @@
+package pipelinegroup
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var ShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Displays a pipeline group's pipelines, and who may view, operate, and administer them",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return show.Run(cmd.Context(), args)
+	},
+}
+
+var show = &ShowRunner{}
+
+type ShowRunner struct{}
+
+func (r *ShowRunner) Run(ctx context.Context, args []string) error {
+	group, _, err := api.DefaultClient.PipelineGroups.Get(ctx, args[0])
+
+	if err != nil {
+		return notFound(err, args[0])
+	}
+
+	utils.Echofln(`Pipeline group:  %s`, group.Name)
+	utils.Echofln(`Pipelines:       %s`, orNone(strings.Join(api.Names(group.Pipelines), `, `)))
+	utils.Echofln(`View:            %s`, orNone(describeGrantees(group, api.PermissionView)))
+	utils.Echofln(`Operate:         %s`, orNone(describeGrantees(group, api.PermissionOperate)))
+	utils.Echofln(`Admin:           %s`, orNone(describeGrantees(group, api.PermissionAdmin)))
+	return nil
+}
+
+func orNone(s string) string {
+	if "" == s {
+		return `(none)`
+	}
+	return s
+}
+
+func init() {
+	RootCmd.AddCommand(ShowCmd)
+}
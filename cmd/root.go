@@ -1,26 +1,57 @@
 package cmd
 
 import (
+	"context"
+	"time"
+
 	"github.com/gocd-contrib/gocd-cli/cfg"
+	"github.com/gocd-contrib/gocd-cli/cmd/agent"
+	"github.com/gocd-contrib/gocd-cli/cmd/artifact"
 	"github.com/gocd-contrib/gocd-cli/cmd/config"
 	"github.com/gocd-contrib/gocd-cli/cmd/configrepo"
+	"github.com/gocd-contrib/gocd-cli/cmd/environment"
+	"github.com/gocd-contrib/gocd-cli/cmd/job"
+	"github.com/gocd-contrib/gocd-cli/cmd/pipeline"
+	"github.com/gocd-contrib/gocd-cli/cmd/pipelinegroup"
+	"github.com/gocd-contrib/gocd-cli/cmd/server"
+	"github.com/gocd-contrib/gocd-cli/cmd/stage"
 	"github.com/gocd-contrib/gocd-cli/utils"
 	"github.com/spf13/cobra"
 )
 
 var RootCmd = &cobra.Command{
-	Use:       "gocd",
-	Short:     "A command-line companion to a GoCD server",
-	ValidArgs: []string{"config", "configrepo", "help"}, // bash-completion
+	Use:           "gocd",
+	Short:         "A command-line companion to a GoCD server",
+	ValidArgs:     []string{"config", "configrepo", "pipeline", "stage", "job", "artifact", "agent", "environment", "pipeline-group", "server", "help"}, // bash-completion
+	SilenceErrors: true,                                                                                                                                 // reported by exitWithError()
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// Arguments have been validated by now; runtime errors should not
+		// print usage
+		cmd.SilenceUsage = true
+
+		ctx, cancel := withTimeout(cmd.Context(), timeout)
+		cancelTimeout = cancel
+		cmd.SetContext(ctx)
+	},
 }
 
 var cfgFile string
+var profile string
+var retries int
+var timeout time.Duration
+var cancelTimeout = func() {}
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the RootCmd.
 func Execute() {
-	if err := RootCmd.Execute(); err != nil {
-		utils.AbortLoudly(err)
+	ctx, stop := interruptibleContext(context.Background())
+	defer stop()
+	defer func() { cancelTimeout() }()
+
+	if err := RootCmd.ExecuteContext(ctx); err != nil {
+		cancelTimeout()
+		stop()
+		exitWithError(err)
 	}
 }
 
@@ -31,13 +62,40 @@ func init() {
 		} else {
 			utils.Debug("Loaded config from: %s", cfg.Conf().ConfigFile())
 		}
+
+		if "" != profile {
+			if err := cfg.Conf().SelectProfile(profile); err != nil {
+				utils.AbortLoudly(err)
+			}
+		} else if err := cfg.Conf().ValidateProfileEnv(); err != nil {
+			utils.AbortLoudly(err)
+		}
+
+		utils.Debug("Using profile: %s", cfg.Conf().ActiveProfile())
+
+		if RootCmd.PersistentFlags().Changed("retries") {
+			if err := cfg.Conf().OverrideRetries(retries); err != nil {
+				utils.AbortLoudly(err)
+			}
+		}
 	})
 
 	RootCmd.AddCommand(config.RootCmd)
 	RootCmd.AddCommand(configrepo.RootCmd)
+	RootCmd.AddCommand(pipeline.RootCmd)
+	RootCmd.AddCommand(stage.RootCmd)
+	RootCmd.AddCommand(job.RootCmd)
+	RootCmd.AddCommand(artifact.RootCmd)
+	RootCmd.AddCommand(agent.RootCmd)
+	RootCmd.AddCommand(environment.RootCmd)
+	RootCmd.AddCommand(pipelinegroup.RootCmd)
+	RootCmd.AddCommand(server.RootCmd)
 	RootCmd.AddCommand(AboutCommand)
 
 	RootCmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", "", "config file (default is $HOME/.gocd/settings.yaml)")
+	RootCmd.PersistentFlags().StringVar(&profile, "profile", "", "server profile to use for this invocation (default is the current profile, or $GOCDCLI_PROFILE)")
+	RootCmd.PersistentFlags().IntVar(&retries, "retries", 0, "number of times to retry API requests after transient failures (default is from config, or 2)")
+	RootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 0, "abort if the command does not complete within this duration (e.g., 30s, 5m); no timeout by default")
 	RootCmd.PersistentFlags().BoolVarP(&utils.SuppressOutput, "quiet", "q", false, "silence output")
 	RootCmd.PersistentFlags().BoolVarP(&utils.DebugMode, "debug", "X", false, "debug output; overrides --quiet")
 }
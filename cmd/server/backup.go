@@ -0,0 +1,96 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var BackupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Backs up the server's configuration and database",
+	Long: strings.Trim(`
+Starts a backup of the server's configuration and database, into the backup
+location configured on the server.
+
+With --wait, follows the backup through each of its steps until it finishes, and
+exits non-zero unless it completes.`, "\n"),
+	Example: strings.Trim(`
+  gocd server backup --wait`, "\n"),
+	Args: cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return backup.Run(cmd.Context(), args)
+	},
+}
+
+var backup = &BackupRunner{}
+
+type BackupRunner struct {
+	Wait         bool
+	PollInterval time.Duration
+}
+
+func (r *BackupRunner) Run(ctx context.Context, args []string) error {
+	server := api.DefaultClient.Server
+	uri, err := server.Backup(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	utils.Echofln(`Backup started`)
+
+	if !r.Wait {
+		return nil
+	}
+
+	var status *api.Backup
+	printed := ``
+
+	if err := utils.Poll(ctx, r.PollInterval, func() (bool, error) {
+		var err error
+
+		if status, err = server.BackupStatus(ctx, uri); err != nil {
+			return false, err
+		}
+
+		if step := describeStep(status); printed != step {
+			utils.Echofln(`  %s`, step)
+			printed = step
+		}
+
+		return status.IsDone(), nil
+	}); err != nil {
+		return err
+	}
+
+	if !status.IsSuccessful() {
+		return &utils.QuietError{Message: fmt.Sprintf(`Backup %s`, strings.ToLower(status.Status))}
+	}
+
+	if "" != status.Path {
+		utils.Echofln(`Backup completed: %s`, status.Path)
+	} else {
+		utils.Echofln(`Backup completed`)
+	}
+
+	return nil
+}
+
+func describeStep(status *api.Backup) string {
+	if "" == status.Message {
+		return status.ProgressStatus
+	}
+	return status.Message
+}
+
+func init() {
+	BackupCmd.Flags().BoolVar(&backup.Wait, "wait", false, "follow the backup until it finishes; exits non-zero unless it completes")
+	BackupCmd.Flags().DurationVar(&backup.PollInterval, "poll-interval", 2*time.Second, "how often to check progress with --wait")
+	RootCmd.AddCommand(BackupCmd)
+}
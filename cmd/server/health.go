@@ -0,0 +1,60 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var HealthCmd = &cobra.Command{
+	Use:   "health",
+	Short: "Displays whether the server is up, and any problems it reports",
+	Args:  cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return health.Run(cmd.Context(), args)
+	},
+}
+
+var health = &HealthRunner{}
+
+type HealthRunner struct{}
+
+func (r *HealthRunner) Run(ctx context.Context, args []string) error {
+	server := api.DefaultClient.Server
+	h, err := server.Health(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	utils.Echofln(`Health:   %s`, h.Health)
+
+	messages, err := server.HealthMessages(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	if 0 == len(messages) {
+		utils.Echofln(`Messages: (none)`)
+		return nil
+	}
+
+	utils.Echofln(`Messages:`)
+
+	for _, m := range messages {
+		utils.Echofln(`  [%s] %s`, m.Level, m.Message)
+
+		if "" != m.Detail {
+			utils.Echofln(`    %s`, m.Detail)
+		}
+	}
+
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(HealthCmd)
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var MaintenanceCmd = &cobra.Command{
+	Use:   "maintenance on|off|status",
+	Short: "Turns maintenance mode on or off, or displays it along with what is still running",
+	Long: strings.Trim(`
+Turns maintenance mode on or off, or displays it. In maintenance mode, the server
+finishes running builds and material updates, but starts no new ones; it is safe
+to shut down once nothing is running, as listed by "status".`, "\n"),
+	Example: strings.Trim(`
+  gocd server maintenance on
+  gocd server maintenance status`, "\n"),
+	ValidArgs: []string{"on", "off", "status"},
+	Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return maintenance.Run(cmd.Context(), args)
+	},
+}
+
+var maintenance = &MaintenanceRunner{}
+
+type MaintenanceRunner struct{}
+
+func (r *MaintenanceRunner) Run(ctx context.Context, args []string) error {
+	server := api.DefaultClient.Server
+
+	switch args[0] {
+	case `on`, `off`:
+		if err := server.SetMaintenanceMode(ctx, `on` == args[0]); err != nil {
+			return err
+		}
+
+		utils.Echofln(`Maintenance mode is %s`, args[0])
+		return nil
+	default:
+		info, err := server.MaintenanceMode(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		printMaintenanceMode(info)
+		return nil
+	}
+}
+
+func printMaintenanceMode(info *api.MaintenanceModeInfo) {
+	if !info.Enabled {
+		utils.Echofln(`Maintenance mode:  off`)
+		return
+	}
+
+	utils.Echofln(`Maintenance mode:  on, since %s by %s`, info.Metadata.UpdatedOn, info.Metadata.UpdatedBy)
+
+	if nil == info.Attributes {
+		return
+	}
+
+	if !info.Attributes.HasRunningSystems {
+		utils.Echofln(`Running:           nothing; safe to shut down`)
+		return
+	}
+
+	utils.Echofln(`Running:           the following; wait for them before shutting down`)
+
+	running := info.Attributes.RunningSystems
+	printJobs(`Building jobs`, running.BuildingJobs)
+	printJobs(`Scheduled jobs`, running.ScheduledJobs)
+
+	if len(running.MaterialUpdates) > 0 {
+		utils.Echofln(`  Material updates:`)
+
+		for _, m := range running.MaterialUpdates {
+			utils.Echofln(`    %s (since %s)`, describeMaterial(m), m.StartTime)
+		}
+	}
+}
+
+func printJobs(label string, jobs []*api.JobResult) {
+	if 0 == len(jobs) {
+		return
+	}
+
+	utils.Echofln(`  %s:`, label)
+
+	for _, j := range jobs {
+		loc := &api.JobLocator{StageLocator: api.StageLocator{Pipeline: j.PipelineName, PipelineCounter: int(j.PipelineCounter), Stage: j.StageName, StageCounter: int(j.StageCounter)}, Job: j.Name}
+		utils.Echofln(`    %s (%s)`, loc, j.State)
+	}
+}
+
+// e.g., `git https://github.com/gocd/gocd`
+func describeMaterial(m *api.MaterialUpdate) string {
+	for _, key := range []string{`url`, `name`, `pipeline`} {
+		if v, ok := m.Attributes[key]; ok && nil != v && "" != v {
+			return fmt.Sprintf(`%s %v`, m.Type, v)
+		}
+	}
+	return m.Type
+}
+
+func init() {
+	RootCmd.AddCommand(MaintenanceCmd)
+}
@@ -0,0 +1,13 @@
+package server
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// RootCmd represents the server command
+var RootCmd = &cobra.Command{
+	Use:       "server",
+	Short:     "GoCD server operations",
+	Long:      `Check the server's health and version, put it into maintenance mode, and back it up, e.g., around upgrades`,
+	ValidArgs: []string{"health", "version", "maintenance", "backup", "help"}, // bash-completion
+}
@@ -0,0 +1,53 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/cfg"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var VersionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Displays the server's version",
+	Args:  cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return version.Run(cmd.Context(), args)
+	},
+}
+
+var version = &VersionRunner{}
+
+type VersionRunner struct{}
+
+func (r *VersionRunner) Run(ctx context.Context, args []string) error {
+	info, err := api.DefaultClient.ServerInfo(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	// refreshes the cached version used to pick API versions, e.g., after an
+	// upgrade
+	if conf := cfg.Conf(); "" != info.Version {
+		if err := conf.CacheServerVersion(conf.GetServerUrl(), info.Version); err != nil {
+			utils.Debug(`failed to cache server version: %v`, err)
+		}
+	}
+
+	utils.Echofln(`Version:   %s`, info.Version)
+	utils.Echofln(`Build:     %s`, info.BuildNumber)
+	utils.Echofln(`Git SHA:   %s`, info.GitSha)
+
+	if "" != info.CommitUrl {
+		utils.Echofln(`Commit:    %s`, info.CommitUrl)
+	}
+
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(VersionCmd)
+}
@@ -0,0 +1,46 @@
+package stage
+
+import (
+	"context"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var CancelCmd = &cobra.Command{
+	Use:     "cancel <pipeline>/<counter>/<stage>[/<counter>]",
+	Short:   "Cancels a running stage",
+	Example: `  gocd stage cancel my-pipeline/latest/deploy`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cancel.Run(cmd.Context(), args)
+	},
+}
+
+var cancel = &CancelRunner{}
+
+type CancelRunner struct{}
+
+func (r *CancelRunner) Run(ctx context.Context, args []string) error {
+	loc, err := api.DefaultClient.LocateStage(ctx, args[0])
+
+	if err != nil {
+		return err
+	}
+
+	if msg, err := api.DefaultClient.Stages.Cancel(ctx, loc); err != nil {
+		return notFound(err, loc)
+	} else {
+		utils.Echofln(msg)
+	}
+	return nil
+}
+
+func notFound(err error, loc *api.StageLocator) error {
+	return api.NotFound(err, `No such stage run: %s`, loc)
+}
+
+func init() {
+	RootCmd.AddCommand(CancelCmd)
+}
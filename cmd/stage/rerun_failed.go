@@ -0,0 +1,42 @@
+package stage
+
+import (
+	"context"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var RerunFailedCmd = &cobra.Command{
+	Use:     "rerun-failed <pipeline>/<counter>/<stage>[/<counter>]",
+	Short:   "Reruns the failed jobs of a stage",
+	Example: `  gocd stage rerun-failed my-pipeline/42/test`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return rerunFailed.Run(cmd.Context(), args)
+	},
+}
+
+var rerunFailed = &RerunFailedRunner{}
+
+type RerunFailedRunner struct{}
+
+func (r *RerunFailedRunner) Run(ctx context.Context, args []string) error {
+	loc, err := api.DefaultClient.LocateStage(ctx, args[0])
+
+	if err != nil {
+		return err
+	}
+
+	if msg, err := api.DefaultClient.Stages.RunFailedJobs(ctx, loc); err != nil {
+		return notFound(err, loc)
+	} else {
+		utils.Echofln(msg)
+	}
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(RerunFailedCmd)
+}
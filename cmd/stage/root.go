@@ -0,0 +1,15 @@
+package stage
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// RootCmd represents the stage command
+var RootCmd = &cobra.Command{
+	Use:   "stage",
+	Short: "GoCD stage operations",
+	Long: `Cancel, run, and rerun the failed jobs of pipeline stages. Stages are located as
+pipeline/counter/stage[/counter], where counters may be "latest"; an omitted
+stage counter also means the latest run of the stage.`,
+	ValidArgs: []string{"cancel", "run", "rerun-failed", "help"}, // bash-completion
+}
@@ -0,0 +1,60 @@
+package stage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gocd-contrib/gocd-cli/api"
+	"github.com/gocd-contrib/gocd-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var RunCmd = &cobra.Command{
+	Use:   "run <pipeline>/<counter>/<stage>",
+	Short: "Runs a stage of an existing pipeline run",
+	Long: strings.Trim(`
+Runs a stage of an existing pipeline run, e.g., to approve a stage with manual
+approval, or reruns it in full if it has already run.`, "\n"),
+	Example: `  gocd stage run my-pipeline/latest/deploy`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return run.Run(cmd.Context(), args)
+	},
+}
+
+var run = &RunRunner{}
+
+type RunRunner struct{}
+
+func (r *RunRunner) Run(ctx context.Context, args []string) error {
+	loc, err := api.ParseStageLocator(args[0])
+
+	if err != nil {
+		return err
+	}
+
+	// a new run of the stage gets a new counter
+	if 2 != strings.Count(args[0], `/`) {
+		return fmt.Errorf(`Invalid stage locator %q; expected pipeline/counter/stage, without a stage counter`, args[0])
+	}
+
+	client := api.DefaultClient
+
+	if api.LatestCounter == loc.PipelineCounter {
+		if loc.PipelineCounter, err = client.Pipelines.LatestCounter(ctx, loc.Pipeline); err != nil {
+			return err
+		}
+	}
+
+	if msg, err := client.Stages.Run(ctx, loc.Pipeline, loc.PipelineCounter, loc.Stage); err != nil {
+		return api.NotFound(err, `No such pipeline run or stage: %s/%d/%s`, loc.Pipeline, loc.PipelineCounter, loc.Stage)
+	} else {
+		utils.Echofln(msg)
+	}
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(RunCmd)
+}
@@ -7,7 +7,16 @@ import (
 )
 
 type Client struct {
-	native *http.Client
+	native     *http.Client
+	retry      *RetryPolicy
+	middleware []Middleware
+}
+
+// Sets the default RetryPolicy for requests built by this client; nil
+// disables retries
+func (c *Client) Retry(policy *RetryPolicy) *Client {
+	c.retry = policy
+	return c
 }
 
 func (c *Client) Get(url string) *Request {
@@ -47,17 +56,23 @@ func (c *Client) Options(url string) *Request {
 }
 
 func (c *Client) buildRequest(method, url string) *Request {
-	return &Request{Url: url, Method: method, c: c}
+	return &Request{Url: url, Method: method, retry: c.retry, c: c}
 }
 
 // Returns a dub.Client instance with a preconfigured http.Transport
 func New() *Client {
-	return Make(&http.Transport{
+	return Make(NewTransport())
+}
+
+// Returns the preconfigured http.Transport used by New(), so callers may
+// customize it (e.g., TLS settings) before passing it to Make()
+func NewTransport() *http.Transport {
+	return &http.Transport{
 		Dial: (&net.Dialer{
 			Timeout: 30 * time.Second,
 		}).Dial,
 		TLSHandshakeTimeout: 5 * time.Second,
-	})
+	}
 }
 
 // Returns a dub.Client instance allowing the user to specify their own http.Transport
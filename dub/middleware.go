@@ -0,0 +1,30 @@
+package dub
+
+import "net/http"
+
+// Sends a native request and returns its response; the innermost Handler
+// performs the actual round trip
+type Handler func(*http.Request) (*http.Response, error)
+
+// Wraps a Handler to add behavior to every request sent by a Client (e.g.,
+// extra headers, logging, metrics); a middleware may short-circuit by not
+// calling `next`
+type Middleware func(next Handler) Handler
+
+// Adds middleware to the client. The first middleware added is the
+// outermost, i.e., it sees the request first and the response last. Each
+// retry attempt passes through the full chain.
+func (c *Client) Use(middleware ...Middleware) *Client {
+	c.middleware = append(c.middleware, middleware...)
+	return c
+}
+
+func (c *Client) handler() Handler {
+	h := Handler(c.native.Do)
+
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		h = c.middleware[i](h)
+	}
+
+	return h
+}
@@ -0,0 +1,73 @@
+package dub
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestMiddlewareWrapsRequestsInOrder(t *testing.T) {
+	as := asserts(t)
+
+	var calls []string
+
+	record := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" before")
+				req.Header.Add("X-Chain", name)
+				res, err := next(req)
+				calls = append(calls, name+" after")
+				return res, err
+			}
+		}
+	}
+
+	c := testCl(func(r *http.Request) (*http.Response, error) {
+		calls = append(calls, "send")
+		as.eq("outer,inner", strings.Join(r.Header.Values("X-Chain"), ","))
+		return okResp(), nil
+	}).Use(record("outer"), record("inner"))
+
+	as.ok(c.Get("http://test").Do(context.Background(), ignoreResponse))
+	as.eq("outer before,inner before,send,inner after,outer after", strings.Join(calls, ","))
+}
+
+func TestMiddlewareCanShortCircuit(t *testing.T) {
+	as := asserts(t)
+
+	c := testCl(func(r *http.Request) (*http.Response, error) {
+		t.Error("Request should not be sent!")
+		return okResp(), nil
+	}).Use(func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			return resp(418, "teapot"), nil
+		}
+	})
+
+	var status int
+	as.ok(c.Get("http://test").Do(context.Background(), func(res *Response) error {
+		status = res.Status
+		return ignoreResponse(res)
+	}))
+	as.eq(418, status)
+}
+
+func TestMiddlewareSeesEachRetry(t *testing.T) {
+	as := asserts(t)
+	stubSleep(t)
+
+	seen := 0
+	c := testCl(func(r *http.Request) (*http.Response, error) {
+		return resp(503, "unavailable"), nil
+	}).Retry(&RetryPolicy{MaxAttempts: 3}).Use(func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			seen++
+			return next(req)
+		}
+	})
+
+	as.ok(c.Get("http://test").Do(context.Background(), ignoreResponse))
+	as.eq(3, seen)
+}
@@ -20,6 +20,7 @@ type Opts struct {
 	ContentType  string
 	OnProgress   []ProgressHandler
 	OnBeforeSend []RawRequestHandler
+	Retry        *RetryPolicy
 }
 
 var methodsCanHaveBody = map[string]struct{}{
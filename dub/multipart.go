@@ -2,6 +2,7 @@ package dub
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"mime/multipart"
@@ -49,12 +50,14 @@ func (m *Multipart) Len() int {
 	return m.MultipartPayload.Len()
 }
 
-func (m *Multipart) Assemble() error {
+// Assembles the payload from its parts; canceling the context aborts
+// assembly, including any assembly that happens concurrently with reads
+func (m *Multipart) Assemble(ctx context.Context) error {
 	if m.MultipartPayload.Ready() {
 		return errors.New("This multipart stream has already been assembled")
 	}
 
-	return m.MultipartPayload.DoAssemble(m.w, m.Parts)
+	return m.MultipartPayload.DoAssemble(ctx, m.w, m.Parts)
 }
 
 func NewPipedMultipart() *Multipart {
@@ -1,6 +1,7 @@
 package dub
 
 import (
+	"context"
 	"errors"
 	"io"
 	"mime/multipart"
@@ -13,7 +14,7 @@ type MultipartPayload interface {
 	io.ReadCloser
 	Len() int
 	Ready() bool
-	DoAssemble(*multipart.Writer, []Part) error
+	DoAssemble(context.Context, *multipart.Writer, []Part) error
 }
 
 func NewPipedPayload(pr *io.PipeReader, pw *io.PipeWriter) MultipartPayload {
@@ -59,10 +60,14 @@ func (p *allocPayload) Close() error {
 	return nil
 }
 
-func (p *allocPayload) DoAssemble(w *multipart.Writer, parts []Part) (err error) {
+func (p *allocPayload) DoAssemble(ctx context.Context, w *multipart.Writer, parts []Part) (err error) {
 	p.once.Do(func() {
 		if len(parts) > 0 {
 			for _, p := range parts {
+				if err = context.Cause(ctx); err != nil {
+					return
+				}
+
 				if err = p.Build(w); err != nil {
 					return
 				}
@@ -120,16 +125,29 @@ func (p *pipedPayload) Close() error {
 	return p.pr.Close()
 }
 
-func (p *pipedPayload) DoAssemble(w *multipart.Writer, parts []Part) error {
+func (p *pipedPayload) DoAssemble(ctx context.Context, w *multipart.Writer, parts []Part) error {
 	p.once.Do(func() {
 		p.asmErrCh = make(chan error, 1)
 
+		// Fails any pending write so the assembling goroutine exits when
+		// the context is canceled, even if nothing reads the pipe
+		stop := context.AfterFunc(ctx, func() {
+			p.pr.CloseWithError(context.Cause(ctx))
+		})
+
 		go func(ec chan<- error) {
 			defer func() { close(ec) }()
 			defer p.pw.Close()
+			defer stop()
 
 			if len(parts) > 0 {
 				for _, p := range parts {
+					if err := context.Cause(ctx); err != nil {
+						ec <- err
+
+						return
+					}
+
 					if err := p.Build(w); err != nil {
 						ec <- err
 
@@ -190,6 +208,6 @@ func (p *wiretapPayload) Ready() bool {
 	return p.delegate.Ready()
 }
 
-func (p *wiretapPayload) DoAssemble(w *multipart.Writer, parts []Part) error {
-	return p.delegate.DoAssemble(w, parts)
+func (p *wiretapPayload) DoAssemble(ctx context.Context, w *multipart.Writer, parts []Part) error {
+	return p.delegate.DoAssemble(ctx, w, parts)
 }
@@ -2,9 +2,12 @@ package dub
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"io"
 	"mime/multipart"
 	"testing"
+	"time"
 )
 
 func TestAllocPayload(t *testing.T) {
@@ -22,7 +25,7 @@ func TestAllocPayload(t *testing.T) {
 	as.err("EOF", err)
 	as.eq(0, d)
 
-	as.ok(p.DoAssemble(w, []Part{NewFieldPart("foo", "bar")}))
+	as.ok(p.DoAssemble(context.Background(), w, []Part{NewFieldPart("foo", "bar")}))
 	as.is(p.Ready())
 	as.is(p.Len() > 0)
 
@@ -45,7 +48,7 @@ func TestPipedPayload(t *testing.T) {
 	as.err("Pipe is not ready to read()", err)
 	as.eq(0, d)
 
-	as.ok(p.DoAssemble(w, []Part{NewFieldPart("foo", "bar")}))
+	as.ok(p.DoAssemble(context.Background(), w, []Part{NewFieldPart("foo", "bar")}))
 	as.is(p.Ready())
 	as.eq(-1, p.Len()) // never knows the length of content
 
@@ -54,6 +57,31 @@ func TestPipedPayload(t *testing.T) {
 	as.eq(1, d)
 }
 
+func TestPipedPayloadStopsAssemblingWhenCanceled(t *testing.T) {
+	as := asserts(t)
+	pr, pw := io.Pipe()
+	w := multipart.NewWriter(pw)
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cause := errors.New("interrupted")
+
+	p := NewPipedPayload(pr, pw).(*pipedPayload)
+
+	// nothing reads the pipe, so the assembling goroutine blocks on write
+	as.ok(p.DoAssemble(ctx, w, []Part{NewFieldPart("foo", "bar")}))
+	cancel(cause)
+
+	select {
+	case err := <-p.asmErrCh:
+		as.eq(cause, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("assembly goroutine did not exit after cancellation")
+	}
+
+	_, err := p.Read(make([]byte, 1))
+	as.neq(nil, err)
+}
+
 func TestWiretapPayload(t *testing.T) {
 	as := asserts(t)
 
@@ -75,7 +103,7 @@ func TestWiretapPayload(t *testing.T) {
 	as.err("EOF", err) // errors pass-thru
 	as.eq(0, d)
 
-	as.ok(p.DoAssemble(w, []Part{NewFieldPart("foo", "bar")})) // assemble the inner payload
+	as.ok(p.DoAssemble(context.Background(), w, []Part{NewFieldPart("foo", "bar")})) // assemble the inner payload
 	as.is(wt.Ready())
 	as.is(wt.Len() > 0)
 
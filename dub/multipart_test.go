@@ -1,6 +1,7 @@
 package dub
 
 import (
+	"context"
 	"io/ioutil"
 	"strings"
 	"testing"
@@ -23,7 +24,7 @@ func TestAddField(t *testing.T) {
 
 	_, ok := m.Parts[0].(*FieldPart)
 	as.is(ok)
-	as.ok(m.Assemble())
+	as.ok(m.Assemble(context.Background()))
 	as.is(m.Ready())
 
 	b, err := ioutil.ReadAll(m)
@@ -47,7 +48,7 @@ func TestAddFile(t *testing.T) {
 
 	_, ok := m.Parts[0].(*FilePart)
 	as.is(ok)
-	as.ok(m.Assemble())
+	as.ok(m.Assemble(context.Background()))
 	as.is(m.Ready())
 
 	b, err := ioutil.ReadAll(m)
@@ -72,7 +73,7 @@ func TestAddFileStream(t *testing.T) {
 
 	_, ok := m.Parts[0].(*StreamPart)
 	as.is(ok)
-	as.ok(m.Assemble())
+	as.ok(m.Assemble(context.Background()))
 	as.is(m.Ready())
 
 	b, err := ioutil.ReadAll(m)
@@ -98,7 +99,7 @@ func TestPipedMultipartComposition(t *testing.T) {
 		AddFileStream("files[]", "arbitrary-data.txt", strings.NewReader("streamed content"))
 
 	as.eq(3, len(m.Parts))
-	as.ok(m.Assemble())
+	as.ok(m.Assemble(context.Background()))
 
 	b, err := ioutil.ReadAll(m)
 	as.ok(err)
@@ -132,7 +133,7 @@ func TestAllocMultipartComposition(t *testing.T) {
 		AddFileStream("files[]", "arbitrary-data.txt", strings.NewReader("streamed content"))
 
 	as.eq(3, len(m.Parts))
-	as.ok(m.Assemble())
+	as.ok(m.Assemble(context.Background()))
 
 	b, err := ioutil.ReadAll(m)
 	as.ok(err)
@@ -161,7 +162,7 @@ func TestAllocMultipartCalculatesContentLength(t *testing.T) {
 	m := NewAllocMultipart() // be explicit here in case we decide to change testMultipart()
 	m.w.SetBoundary("testbound")
 
-	as.ok(m.AddField("foo", "bar").Assemble())
+	as.ok(m.AddField("foo", "bar").Assemble(context.Background()))
 	as.eq(79, m.Len()) // show that it calculates before reading
 
 	b, err := ioutil.ReadAll(m)
@@ -175,7 +176,7 @@ func TestPipedMultipartReportsUnknownContentLength(t *testing.T) {
 	m := NewPipedMultipart()
 	m.w.SetBoundary("testbound")
 
-	as.ok(m.AddField("foo", "bar").Assemble())
+	as.ok(m.AddField("foo", "bar").Assemble(context.Background()))
 	as.eq(-1, m.Len())
 
 	b, err := ioutil.ReadAll(m)
@@ -193,11 +194,11 @@ func TestMultipartOnlyReadsAfterAssembly(t *testing.T) {
 
 	_, err := m.Read(make([]byte, 1))
 	as.err("Multipart stream is not ready to read(); call Multipart.Assemble() first", err)
-	as.ok(m.Assemble())
+	as.ok(m.Assemble(context.Background()))
 
 	b, e := m.Read(make([]byte, 1))
 	as.ok(e)
 	as.eq(1, b)
 
-	as.err("This multipart stream has already been assembled", m.Assemble())
+	as.err("This multipart stream has already been assembled", m.Assemble(context.Background()))
 }
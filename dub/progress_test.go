@@ -1,6 +1,7 @@
 package dub
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"testing"
@@ -23,7 +24,7 @@ func TestProgressDuringUpload(t *testing.T) {
 		OnProgress(func(p *Progress) error {
 			result = append(result, p.Current)
 			return nil
-		}).Do(ignoreResponse)
+		}).Do(context.Background(), ignoreResponse)
 
 	as.deepEqI64([]int64{1, 2, 3}, result)
 }
@@ -38,7 +39,7 @@ func TestProgressDuringDownload(t *testing.T) {
 	var result []int64
 
 	c.Get("http://test").
-		Do(func(res *Response) error {
+		Do(context.Background(), func(res *Response) error {
 			return res.OnProgress(func(p *Progress) error {
 				result = append(result, p.Current)
 				return nil
@@ -0,0 +1,100 @@
+package dub
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Explicit proxy settings, in the spirit of http.ProxyFromEnvironment()
+type ProxyConfig struct {
+	HTTPProxy  string
+	HTTPSProxy string
+
+	// Comma-separated hosts, domains, IPs, and CIDR blocks that bypass the
+	// proxy; `*` bypasses the proxy for all requests
+	NoProxy string
+}
+
+// Returns a function suitable for http.Transport.Proxy. As with
+// http.ProxyFromEnvironment(), requests to localhost are never proxied.
+func (p *ProxyConfig) ProxyFunc() func(*http.Request) (*url.URL, error) {
+	return func(req *http.Request) (*url.URL, error) {
+		var proxy string
+
+		switch req.URL.Scheme {
+		case `https`:
+			proxy = p.HTTPSProxy
+		case `http`:
+			proxy = p.HTTPProxy
+		}
+
+		if "" == proxy || !p.UseProxy(req.URL.Host) {
+			return nil, nil
+		}
+
+		if !strings.Contains(proxy, `://`) {
+			proxy = `http://` + proxy
+		}
+
+		if u, err := url.Parse(proxy); err != nil {
+			return nil, wrapErr(err, "Invalid proxy url "+proxy)
+		} else {
+			return u, nil
+		}
+	}
+}
+
+// Reports whether requests to the given host (with optional port) should be
+// sent through the proxy
+func (p *ProxyConfig) UseProxy(hostport string) bool {
+	host, port, err := net.SplitHostPort(hostport)
+
+	if err != nil {
+		host = hostport
+	}
+
+	host = strings.ToLower(strings.Trim(host, `[]`))
+
+	if `localhost` == host {
+		return false
+	}
+
+	ip := net.ParseIP(host)
+
+	if ip != nil && ip.IsLoopback() {
+		return false
+	}
+
+	for _, entry := range strings.FieldsFunc(strings.ToLower(p.NoProxy), func(r rune) bool { return ',' == r || ' ' == r }) {
+		if `*` == entry {
+			return false
+		}
+
+		if _, block, err := net.ParseCIDR(entry); err == nil {
+			if ip != nil && block.Contains(ip) {
+				return false
+			}
+			continue
+		}
+
+		entryHost, entryPort, err := net.SplitHostPort(entry)
+
+		if err != nil {
+			entryHost, entryPort = entry, ``
+		}
+
+		if `` != entryPort && entryPort != port {
+			continue
+		}
+
+		entryHost = strings.TrimPrefix(strings.Trim(entryHost, `[]`), `*`)
+
+		if host == strings.TrimPrefix(entryHost, `.`) || strings.HasSuffix(host, `.`+strings.TrimPrefix(entryHost, `.`)) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,58 @@
+package dub
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestUseProxy(t *testing.T) {
+	as := asserts(t)
+
+	p := &ProxyConfig{NoProxy: `gocd.corp, .internal , 10.0.0.0/8,example.com:8153,[::2]`}
+
+	as.is(p.UseProxy(`github.com`))
+	as.is(p.UseProxy(`api.github.com:443`))
+	as.is(p.UseProxy(`11.0.0.1`))
+	as.is(p.UseProxy(`example.com:443`))
+	as.is(p.UseProxy(`notgocd.corp`))
+
+	as.not(p.UseProxy(`localhost:8153`))
+	as.not(p.UseProxy(`127.0.0.1`))
+	as.not(p.UseProxy(`[::1]:8153`))
+	as.not(p.UseProxy(`gocd.corp`))
+	as.not(p.UseProxy(`GoCD.corp:8154`))
+	as.not(p.UseProxy(`ci.gocd.corp`))
+	as.not(p.UseProxy(`build.internal`))
+	as.not(p.UseProxy(`internal`))
+	as.not(p.UseProxy(`10.1.2.3:8153`))
+	as.not(p.UseProxy(`example.com:8153`))
+	as.not(p.UseProxy(`[::2]:443`))
+
+	as.not((&ProxyConfig{NoProxy: `*`}).UseProxy(`github.com`))
+}
+
+func TestProxyFunc(t *testing.T) {
+	as := asserts(t)
+
+	p := (&ProxyConfig{HTTPProxy: `plain:3128`, HTTPSProxy: `https://secure:3129`, NoProxy: `.internal`}).ProxyFunc()
+
+	req, _ := http.NewRequest(`GET`, `http://github.com/`, nil)
+	u, err := p(req)
+	as.ok(err)
+	as.eq(`http://plain:3128`, u.String())
+
+	req, _ = http.NewRequest(`GET`, `https://github.com/`, nil)
+	u, err = p(req)
+	as.ok(err)
+	as.eq(`https://secure:3129`, u.String())
+
+	req, _ = http.NewRequest(`GET`, `https://gocd.internal/go`, nil)
+	u, err = p(req)
+	as.ok(err)
+	as.is(nil == u)
+
+	req, _ = http.NewRequest(`GET`, `https://github.com/`, nil)
+	u, err = (&ProxyConfig{}).ProxyFunc()(req)
+	as.ok(err)
+	as.is(nil == u)
+}
@@ -1,6 +1,7 @@
 package dub
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -17,6 +18,8 @@ type Request struct {
 
 	onBeforeSend []RawRequestHandler
 	onProgress   []ProgressHandler
+	retry        *RetryPolicy
+	idempotent   bool
 	c            *Client
 }
 
@@ -45,6 +48,10 @@ func (r *Request) Opts(opts *Opts) *Request {
 		r.onBeforeSend = opts.OnBeforeSend
 	}
 
+	if opts.Retry != nil {
+		r.retry = opts.Retry
+	}
+
 	return r
 }
 
@@ -150,21 +157,85 @@ func (r *Request) BeforeSend(handler RawRequestHandler) *Request {
 	return r
 }
 
-func (r *Request) Do(onResponse ResponseHandler) error {
+// Marks the request as safe to retry regardless of its method (e.g., a POST
+// that does not modify server state)
+func (r *Request) Idempotent() *Request {
+	r.idempotent = true
+	return r
+}
+
+// Overrides the client's RetryPolicy for this request; nil disables retries
+func (r *Request) Retry(policy *RetryPolicy) *Request {
+	r.retry = policy
+	return r
+}
+
+// Sends the request, retrying per its RetryPolicy. Canceling the context
+// aborts the request, along with any pending retries; in that case, the
+// context's cause is returned.
+func (r *Request) Do(ctx context.Context, onResponse ResponseHandler) error {
 	if !allowBody(r.Method) && r.Body != nil {
 		return fmt.Errorf("Method `%s` does not accept a request body", r.Method)
 	}
 
-	var body io.Reader
-	var progress *Progress
-
 	if r.Body != nil {
 		if m, ok := r.Body.(*Multipart); ok {
-			if err := m.Assemble(); err != nil {
+			if err := m.Assemble(ctx); err != nil {
 				return wrapErr(err, "Failed to assemble multipart request body stream")
 			}
 		}
+	}
+
+	maxAttempts := 1
+	var replay *replayer
+
+	if r.retry.enabled() && (r.idempotent || idempotent(r.Method)) {
+		if rp, ok := newReplayer(r.Body); ok {
+			maxAttempts, replay = r.retry.MaxAttempts, rp
+		} else {
+			r.retry.notify(&Retry{Request: r, Attempt: 1, MaxAttempts: 1, Err: errNotReplayable})
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		res, err := r.send(ctx)
 
+		if err != nil && ctx.Err() != nil {
+			return context.Cause(ctx)
+		}
+
+		if attempt >= maxAttempts || !(err == nil && retryableStatus(res.StatusCode) || err != nil && retryableErr(err)) {
+			if err != nil {
+				return err
+			}
+
+			return onResponse(newResp(res))
+		}
+
+		if err == nil {
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		delay := r.retry.delay(attempt, res)
+		r.retry.notify(&Retry{Request: r, Attempt: attempt, MaxAttempts: maxAttempts, Err: err, Response: res, Delay: delay})
+
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+
+		if err := replay.rewind(); err != nil {
+			return wrapErr(err, "Failed to rewind request body for retry")
+		}
+	}
+}
+
+// Builds and sends a single native request
+func (r *Request) send(ctx context.Context) (*http.Response, error) {
+	var body io.Reader
+	var progress *Progress
+
+	if r.Body != nil {
 		if len(r.onProgress) > 0 {
 			progress = newProgress(r.bodySize(), r.onProgress)
 			body = io.TeeReader(r.Body, newProgressWriter(progress))
@@ -173,13 +244,15 @@ func (r *Request) Do(onResponse ResponseHandler) error {
 		}
 	}
 
-	if req, errRq := http.NewRequest(r.Method, r.Url, body); errRq == nil {
+	if req, errRq := http.NewRequestWithContext(ctx, r.Method, r.Url, body); errRq == nil {
 
 		if progress != nil {
 			progress.RawRequest = req
 		}
 
-		req.Header = r.Headers
+		if r.Headers != nil {
+			req.Header = r.Headers.Clone()
+		}
 		r.setContentLength(req)
 
 		// Set cookies after header or they will get clobbered when setting other
@@ -197,19 +270,13 @@ func (r *Request) Do(onResponse ResponseHandler) error {
 		if len(r.onBeforeSend) > 0 {
 			for _, h := range r.onBeforeSend {
 				if err := h(req); err != nil {
-					return wrapErr(err, "Request.BeforeSend() hook failed")
+					return nil, wrapErr(err, "Request.BeforeSend() hook failed")
 				}
 			}
 		}
 
-		res, errRs := r.c.native.Do(req)
-
-		if errRs != nil {
-			return errRs
-		}
-
-		return onResponse(newResp(res))
+		return r.c.handler()(req)
 	} else {
-		return wrapErr(errRq, "Failed to build native *http.Request")
+		return nil, wrapErr(errRq, "Failed to build native *http.Request")
 	}
 }
@@ -1,6 +1,7 @@
 package dub
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -49,7 +50,7 @@ func TestRequestConfigByOpts(t *testing.T) {
 	as.eq(1, len(req.onProgress))
 	as.eq(1, len(req.onBeforeSend))
 
-	as.ok(req.Do(ignoreResponse))
+	as.ok(req.Do(context.Background(), ignoreResponse))
 	as.is(didRun)
 }
 
@@ -70,7 +71,7 @@ func TestRequestUrl(t *testing.T) {
 	as.neq(nil, req)
 	as.eq("http://test", req.Url)
 
-	as.ok(req.Do(ignoreResponse))
+	as.ok(req.Do(context.Background(), ignoreResponse))
 	as.is(didRun)
 }
 
@@ -82,12 +83,12 @@ func TestWhichRequestsAcceptBody(t *testing.T) {
 
 	bodyDeny := []*Request{c.Get(url), c.Head(url), c.Connect(url), c.Options(url), c.Trace(url)}
 	for _, req := range bodyDeny {
-		as.err(fmt.Sprintf("Method `%s` does not accept a request body", req.Method), req.DataString("test").Do(ignoreResponse))
+		as.err(fmt.Sprintf("Method `%s` does not accept a request body", req.Method), req.DataString("test").Do(context.Background(), ignoreResponse))
 	}
 
 	bodyAccept := []*Request{c.Delete(url), c.Post(url), c.Put(url), c.Patch(url)}
 	for _, req := range bodyAccept {
-		as.ok(req.DataString("test").Do(ignoreResponse))
+		as.ok(req.DataString("test").Do(context.Background(), ignoreResponse))
 	}
 }
 
@@ -103,7 +104,7 @@ func TestRequestBeforeSendAllowsNativeRequestAccess(t *testing.T) {
 		native = r
 		didRun = true
 		return nil
-	}).Do(ignoreResponse))
+	}).Do(context.Background(), ignoreResponse))
 
 	as.is(didRun)
 	as.neq(nil, native)
@@ -130,7 +131,7 @@ func TestRequestData(t *testing.T) {
 		return okResp(), nil
 	})
 
-	as.ok(c.Put("http://test").Data(strings.NewReader("hello")).Do(ignoreResponse))
+	as.ok(c.Put("http://test").Data(strings.NewReader("hello")).Do(context.Background(), ignoreResponse))
 	as.is(didRun)
 	as.eq("hello", actual)
 }
@@ -154,7 +155,7 @@ func TestRequestDataString(t *testing.T) {
 		return okResp(), nil
 	})
 
-	as.ok(c.Put("http://test").DataString("hello").Do(ignoreResponse))
+	as.ok(c.Put("http://test").DataString("hello").Do(context.Background(), ignoreResponse))
 	as.is(didRun)
 	as.eq("hello", actual)
 }
@@ -171,7 +172,7 @@ func TestRequestAuth(t *testing.T) {
 		return okResp(), nil
 	})
 
-	as.ok(c.Get("http://test").Auth(fakeAuth("secret")).Do(ignoreResponse))
+	as.ok(c.Get("http://test").Auth(fakeAuth("secret")).Do(context.Background(), ignoreResponse))
 	as.is(didRun)
 }
 
@@ -187,7 +188,7 @@ func TestRequestContentType(t *testing.T) {
 		return okResp(), nil
 	})
 
-	as.ok(c.Get("http://test").ContentType("application/json").Do(ignoreResponse))
+	as.ok(c.Get("http://test").ContentType("application/json").Do(context.Background(), ignoreResponse))
 	as.is(didRun)
 }
 
@@ -233,7 +234,7 @@ func TestRequestHeader(t *testing.T) {
 		return okResp(), nil
 	})
 
-	as.ok(c.Get("http://test").Header("Foo", "Bar").Do(ignoreResponse))
+	as.ok(c.Get("http://test").Header("Foo", "Bar").Do(context.Background(), ignoreResponse))
 	as.is(didRun)
 }
 
@@ -258,7 +259,7 @@ func TestRequestCookie(t *testing.T) {
 	as.ok(c.Get("http://test").Cookie(&http.Cookie{
 		Name:  "cookie-monster",
 		Value: "me-want-cookie",
-	}).Do(ignoreResponse))
+	}).Do(context.Background(), ignoreResponse))
 	as.is(didRun)
 }
 
@@ -287,6 +288,6 @@ func TestRequestSetHeaders(t *testing.T) {
 	as.ok(c.Get("http://test").SetHeaders(map[string][]string{
 		"Foo": {"Bar", "Baz"},
 		"Hi":  {"Bye"},
-	}).Do(ignoreResponse))
+	}).Do(context.Background(), ignoreResponse))
 	as.is(didRun)
 }
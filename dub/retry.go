@@ -0,0 +1,211 @@
+package dub
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DEFAULT_RETRY_BASE_DELAY = 500 * time.Millisecond
+	DEFAULT_RETRY_MAX_DELAY  = 30 * time.Second
+)
+
+// Describes how a Request retries after transient failures, i.e., network
+// errors and 429, 502, 503, and 504 responses. Only idempotent requests are
+// retried, and only if the request body (if any) can be replayed.
+type RetryPolicy struct {
+	// Total number of attempts, including the first; values < 2 disable retries
+	MaxAttempts int
+
+	// The delay before the first retry, doubling with each subsequent retry;
+	// defaults to DEFAULT_RETRY_BASE_DELAY
+	BaseDelay time.Duration
+
+	// Upper bound on any single delay, including those requested by a
+	// `Retry-After` header; defaults to DEFAULT_RETRY_MAX_DELAY
+	MaxDelay time.Duration
+
+	// Fraction (0.0 to 1.0) of each delay to randomize so that concurrent
+	// clients do not retry in lockstep
+	Jitter float64
+
+	// Optional observer, invoked before each retry and when a request cannot
+	// be retried because its body cannot be replayed
+	OnRetry func(*Retry)
+}
+
+// Describes a retry (or a skipped one) to RetryPolicy.OnRetry
+type Retry struct {
+	Request     *Request
+	Attempt     int // the attempt that just failed
+	MaxAttempts int
+
+	// Either the network error or the retryable response (whose body has
+	// already been discarded)
+	Err      error
+	Response *http.Response
+
+	Delay time.Duration
+}
+
+var errNotReplayable = errors.New("request body cannot be replayed; not retrying")
+
+var retryableStatuses = map[int]struct{}{
+	http.StatusTooManyRequests:    struct{}{},
+	http.StatusBadGateway:         struct{}{},
+	http.StatusServiceUnavailable: struct{}{},
+	http.StatusGatewayTimeout:     struct{}{},
+}
+
+var idempotentMethods = map[string]struct{}{
+	"get":     struct{}{},
+	"head":    struct{}{},
+	"put":     struct{}{},
+	"delete":  struct{}{},
+	"options": struct{}{},
+	"trace":   struct{}{},
+}
+
+// Waits for the given duration, returning early with the context's cause
+// if it is canceled; replaceable in tests
+var sleep = func(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
+
+func (p *RetryPolicy) enabled() bool {
+	return p != nil && p.MaxAttempts > 1
+}
+
+func (p *RetryPolicy) notify(r *Retry) {
+	if p.OnRetry != nil {
+		p.OnRetry(r)
+	}
+}
+
+// Computes the delay before the given retry (1-based), preferring the
+// server's `Retry-After` header when present
+func (p *RetryPolicy) delay(retry int, res *http.Response) time.Duration {
+	maxDelay := p.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = DEFAULT_RETRY_MAX_DELAY
+	}
+
+	if d, ok := retryAfter(res); ok {
+		return min(d, maxDelay)
+	}
+
+	d := p.BaseDelay
+	if d <= 0 {
+		d = DEFAULT_RETRY_BASE_DELAY
+	}
+
+	for i := 1; i < retry && d < maxDelay; i++ {
+		d *= 2
+	}
+
+	d = min(d, maxDelay)
+
+	if jitter := min(max(p.Jitter, 0), 1); jitter > 0 {
+		d -= time.Duration(jitter * rand.Float64() * float64(d))
+	}
+
+	return d
+}
+
+// Parses the `Retry-After` header as either delay-seconds or an HTTP-date
+func retryAfter(res *http.Response) (time.Duration, bool) {
+	if nil == res {
+		return 0, false
+	}
+
+	val := strings.TrimSpace(res.Header.Get(`Retry-After`))
+
+	if "" == val {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(val); err == nil {
+		return time.Duration(max(secs, 0)) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(val); err == nil {
+		return max(time.Until(t), 0), true
+	}
+
+	return 0, false
+}
+
+func retryableStatus(status int) bool {
+	_, ok := retryableStatuses[status]
+	return ok
+}
+
+func idempotent(method string) bool {
+	_, ok := idempotentMethods[strings.ToLower(method)]
+	return ok
+}
+
+// Network errors are generally worth retrying, but certificate problems
+// will not resolve themselves
+func retryableErr(err error) bool {
+	var unknownAuthority x509.UnknownAuthorityError
+	var invalidCert x509.CertificateInvalidError
+	var hostname x509.HostnameError
+	var verification *tls.CertificateVerificationError
+
+	return !(errors.As(err, &unknownAuthority) ||
+		errors.As(err, &invalidCert) ||
+		errors.As(err, &hostname) ||
+		errors.As(err, &verification))
+}
+
+// Captures the state needed to replay a request body. Only bodies that
+// can seek back to their starting offset are replayable; streams such as
+// a piped Multipart can only be sent once.
+type replayer struct {
+	body   io.Seeker
+	offset int64
+}
+
+func newReplayer(body io.Reader) (*replayer, bool) {
+	if nil == body {
+		return &replayer{}, true
+	}
+
+	if _, ok := body.(*Multipart); ok {
+		return nil, false
+	}
+
+	if s, ok := body.(io.Seeker); ok {
+		if offset, err := s.Seek(0, io.SeekCurrent); err == nil {
+			return &replayer{body: s, offset: offset}, true
+		}
+	}
+
+	return nil, false
+}
+
+func (rp *replayer) rewind() error {
+	if nil == rp.body {
+		return nil
+	}
+
+	_, err := rp.body.Seek(rp.offset, io.SeekStart)
+	return err
+}
@@ -0,0 +1,213 @@
+package dub
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetriesIdempotentRequestsOnRetryableStatus(t *testing.T) {
+	as := asserts(t)
+	delays := stubSleep(t)
+
+	attempts := 0
+	c := testCl(func(r *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts < 3 {
+			return resp(503, "unavailable"), nil
+		}
+		return okResp(), nil
+	}).Retry(&RetryPolicy{MaxAttempts: 3, BaseDelay: 100 * time.Millisecond})
+
+	var status int
+	as.ok(c.Get("http://test").Do(context.Background(), func(res *Response) error {
+		status = res.Status
+		return ignoreResponse(res)
+	}))
+
+	as.eq(3, attempts)
+	as.eq(200, status)
+	as.eq(2, len(*delays))
+	as.eq(100*time.Millisecond, (*delays)[0])
+	as.eq(200*time.Millisecond, (*delays)[1])
+}
+
+func TestRetriesGiveUpAfterMaxAttempts(t *testing.T) {
+	as := asserts(t)
+	stubSleep(t)
+
+	attempts := 0
+	c := testCl(func(r *http.Request) (*http.Response, error) {
+		attempts++
+		return resp(502, "bad gateway"), nil
+	}).Retry(&RetryPolicy{MaxAttempts: 4})
+
+	var status int
+	as.ok(c.Get("http://test").Do(context.Background(), func(res *Response) error {
+		status = res.Status
+		return ignoreResponse(res)
+	}))
+
+	as.eq(4, attempts)
+	as.eq(502, status)
+}
+
+func TestRetriesNetworkErrors(t *testing.T) {
+	as := asserts(t)
+	stubSleep(t)
+
+	attempts := 0
+	c := testCl(func(r *http.Request) (*http.Response, error) {
+		attempts++
+		if 1 == attempts {
+			return nil, errors.New("connection reset by peer")
+		}
+		return okResp(), nil
+	}).Retry(&RetryPolicy{MaxAttempts: 2})
+
+	as.ok(c.Get("http://test").Do(context.Background(), ignoreResponse))
+	as.eq(2, attempts)
+}
+
+func TestDoesNotRetryOtherStatuses(t *testing.T) {
+	as := asserts(t)
+	stubSleep(t)
+
+	attempts := 0
+	c := testCl(func(r *http.Request) (*http.Response, error) {
+		attempts++
+		return resp(500, "boom"), nil
+	}).Retry(&RetryPolicy{MaxAttempts: 3})
+
+	as.ok(c.Get("http://test").Do(context.Background(), ignoreResponse))
+	as.eq(1, attempts)
+}
+
+func TestDoesNotRetryNonIdempotentMethodsUnlessMarked(t *testing.T) {
+	as := asserts(t)
+	stubSleep(t)
+
+	attempts := 0
+	c := testCl(func(r *http.Request) (*http.Response, error) {
+		attempts++
+		return resp(503, "unavailable"), nil
+	}).Retry(&RetryPolicy{MaxAttempts: 3})
+
+	as.ok(c.Post("http://test").DataString("data").Do(context.Background(), ignoreResponse))
+	as.eq(1, attempts)
+
+	attempts = 0
+	as.ok(c.Post("http://test").DataString("data").Idempotent().Do(context.Background(), ignoreResponse))
+	as.eq(3, attempts)
+}
+
+func TestReplaysSeekableBodies(t *testing.T) {
+	as := asserts(t)
+	stubSleep(t)
+
+	var bodies []string
+	c := testCl(func(r *http.Request) (*http.Response, error) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if len(bodies) < 2 {
+			return resp(504, "timeout"), nil
+		}
+		return okResp(), nil
+	}).Retry(&RetryPolicy{MaxAttempts: 2})
+
+	body := strings.NewReader("skip:payload")
+	body.Seek(5, io.SeekStart)
+
+	as.ok(c.Put("http://test").Data(body).Do(context.Background(), ignoreResponse))
+	as.eq(2, len(bodies))
+	as.eq("payload", bodies[0])
+	as.eq("payload", bodies[1])
+}
+
+func TestDoesNotRetryNonReplayableBodies(t *testing.T) {
+	as := asserts(t)
+	stubSleep(t)
+
+	attempts := 0
+	c := testCl(func(r *http.Request) (*http.Response, error) {
+		attempts++
+		io.Copy(io.Discard, r.Body)
+		return resp(503, "unavailable"), nil
+	})
+
+	var skipped *Retry
+	policy := &RetryPolicy{MaxAttempts: 3, OnRetry: func(r *Retry) { skipped = r }}
+
+	as.ok(c.Put("http://test").Data(NewPipedMultipart().AddField("a", "b")).Retry(policy).Do(context.Background(), ignoreResponse))
+	as.eq(1, attempts)
+	as.neq(nil, skipped)
+	as.eq(errNotReplayable, skipped.Err)
+
+	attempts = 0
+	as.ok(c.Put("http://test").Data(io.MultiReader(strings.NewReader("stream"))).Retry(policy).Do(context.Background(), ignoreResponse))
+	as.eq(1, attempts)
+}
+
+func TestRetryPolicyDelays(t *testing.T) {
+	as := asserts(t)
+
+	p := &RetryPolicy{MaxAttempts: 10, BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+	as.eq(time.Second, p.delay(1, nil))
+	as.eq(2*time.Second, p.delay(2, nil))
+	as.eq(4*time.Second, p.delay(3, nil))
+	as.eq(5*time.Second, p.delay(4, nil))
+	as.eq(5*time.Second, p.delay(50, nil))
+
+	as.eq(DEFAULT_RETRY_BASE_DELAY, (&RetryPolicy{}).delay(1, nil))
+
+	res := resp(429, "slow down")
+	res.Header.Set("Retry-After", "3")
+	as.eq(3*time.Second, p.delay(1, res))
+
+	res.Header.Set("Retry-After", "120")
+	as.eq(5*time.Second, p.delay(1, res))
+
+	res.Header.Set("Retry-After", time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	as.eq(time.Duration(0), p.delay(1, res))
+
+	res.Header.Set("Retry-After", "garbage")
+	as.eq(time.Second, p.delay(1, res))
+
+	jittery := &RetryPolicy{BaseDelay: time.Second, Jitter: 0.5}
+	for i := 0; i < 20; i++ {
+		d := jittery.delay(1, nil)
+		as.is(d > 500*time.Millisecond-1 && d <= time.Second)
+	}
+}
+
+func TestCancelingStopsRetries(t *testing.T) {
+	as := asserts(t)
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cause := errors.New("interrupted")
+
+	attempts := 0
+	c := testCl(func(r *http.Request) (*http.Response, error) {
+		attempts++
+		cancel(cause)
+		return resp(503, "unavailable"), nil
+	}).Retry(&RetryPolicy{MaxAttempts: 3, BaseDelay: time.Hour})
+
+	as.eq(cause, c.Get("http://test").Do(ctx, ignoreResponse))
+	as.eq(1, attempts)
+}
+
+func stubSleep(t *testing.T) *[]time.Duration {
+	delays := &[]time.Duration{}
+	original := sleep
+	sleep = func(ctx context.Context, d time.Duration) error {
+		*delays = append(*delays, d)
+		return context.Cause(ctx)
+	}
+	t.Cleanup(func() { sleep = original })
+	return delays
+}
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// Returns the user's preferred editor command, from $VISUAL or $EDITOR
+func Editor() string {
+	for _, env := range []string{`VISUAL`, `EDITOR`} {
+		if e := strings.TrimSpace(os.Getenv(env)); "" != e {
+			return e
+		}
+	}
+
+	if `windows` == runtime.GOOS {
+		return `notepad`
+	}
+	return `vi`
+}
+
+// Opens a file in the user's editor and waits for it to exit. The editor
+// command may include arguments (e.g., `code --wait`).
+func EditFile(path string) error {
+	editor := Editor()
+	cmd := ShellCommand(editor + ` ` + shellQuote(path))
+
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf(`Editor %q failed: %v`, editor, err)
+	}
+	return nil
+}
+
+func shellQuote(s string) string {
+	if `windows` == runtime.GOOS {
+		return `"` + s + `"`
+	}
+	return `'` + strings.ReplaceAll(s, `'`, `'\''`) + `'`
+}
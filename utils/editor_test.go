@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestEditorPrefersVisual(t *testing.T) {
+	as := asserts(t)
+
+	t.Setenv(`VISUAL`, `code --wait`)
+	t.Setenv(`EDITOR`, `nano`)
+	as.eq(`code --wait`, Editor())
+
+	t.Setenv(`VISUAL`, ``)
+	as.eq(`nano`, Editor())
+}
+
+func TestEditFile(t *testing.T) {
+	as := asserts(t)
+	path := filepath.Join(t.TempDir(), `it's here.json`)
+	as.ok(ioutil.WriteFile(path, []byte(`before`), 0644))
+
+	// an "editor" that appends to the file it is given
+	t.Setenv(`VISUAL`, `sh -c 'echo " after" >> "$1"' --`)
+	as.ok(EditFile(path))
+
+	b, err := ioutil.ReadFile(path)
+	as.ok(err)
+	as.eq("before after\n", string(b))
+
+	t.Setenv(`VISUAL`, `false`)
+	as.err(`Editor "false" failed: exit status 1`, EditFile(path))
+}
@@ -0,0 +1,20 @@
+package utils
+
+// An error for an expected failure whose message has either already been
+// displayed, or should be silenced by `--quiet` (e.g., a failed syntax
+// check). Exits with Code, or 1 if unset.
+type QuietError struct {
+	Code    int
+	Message string
+}
+
+func (e *QuietError) Error() string {
+	return e.Message
+}
+
+func (e *QuietError) ExitCode() int {
+	if 0 == e.Code {
+		return 1
+	}
+	return e.Code
+}
@@ -4,6 +4,7 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"runtime"
 )
 
 func ExecQ(cmd *exec.Cmd) bool {
@@ -25,3 +26,12 @@ func Exec(cmd *exec.Cmd, pipeIn io.Reader, pipeOut io.Writer, pipeErr io.Writer)
 
 	return err == nil
 }
+
+// Builds a command to be interpreted by the platform's shell so that
+// user-supplied commands may include arguments, pipes, etc.
+func ShellCommand(command string) *exec.Cmd {
+	if `windows` == runtime.GOOS {
+		return exec.Command(`cmd`, `/C`, command)
+	}
+	return exec.Command(`sh`, `-c`, command)
+}
@@ -40,3 +40,11 @@ func testIn() io.Reader {
 func testOut() *strings.Builder {
 	return &strings.Builder{}
 }
+
+func TestShellCommand(t *testing.T) {
+	as := asserts(t)
+	out := testOut()
+
+	as.is(Exec(ShellCommand(`echo hello | tr a-z A-Z`), testIn(), out, testOut()))
+	as.eq("HELLO\n", out.String())
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"bufio"
+	"context"
 	"io"
 	"os"
 	"path"
@@ -21,43 +22,51 @@ func downloadProgress(dp *dub.Progress) error {
 	return nil
 }
 
-func Wget(url string, name string, destFolder string) (filepath string, err error) {
-	tmpfile := path.Join(destFolder, "_"+name+".partialdownload")
-	filepath = path.Join(destFolder, name)
-
+func Wget(ctx context.Context, client *dub.Client, url string, name string, destFolder string) (filepath string, err error) {
 	Echofln("Downloading %s", url)
 
-	err = dub.New().Get(url).Do(func(res *dub.Response) (err error) {
-		var file *os.File
+	err = client.Get(url).Do(ctx, func(res *dub.Response) (err error) {
+		filepath, err = SaveDownload(res, name, destFolder)
+		return err
+	})
 
-		if file, err = os.Create(tmpfile); err != nil {
-			return InspectError(err, `creating tempfile %q for downloading %q`, tmpfile, name)
-		}
+	return filepath, err
+}
 
-		defer file.Close() // ensure we close the file handle even if we abort early
+// Saves a response body as destFolder/name, displaying progress. Writes to a
+// `.partialdownload` temp file first, so that an interrupted download never
+// leaves a truncated file under the final name.
+func SaveDownload(res *dub.Response, name string, destFolder string) (filepath string, err error) {
+	tmpfile := path.Join(destFolder, "_"+name+".partialdownload")
+	filepath = path.Join(destFolder, name)
 
-		if err = res.OnProgress(downloadProgress).Consume(func(body io.Reader) error {
-			w := bufio.NewWriter(file)
-			_, err := io.Copy(w, body)
+	var file *os.File
 
-			if err != nil {
-				return InspectError(err, `writing downloaded data to file %q`, file.Name())
-			}
+	if file, err = os.Create(tmpfile); err != nil {
+		return filepath, InspectError(err, `creating tempfile %q for downloading %q`, tmpfile, name)
+	}
 
-			return w.Flush()
-		}); err != nil {
-			return InspectError(err, `updating file download progress for %q`, url)
-		}
+	defer file.Close() // ensure we close the file handle even if we abort early
 
-		// explicitly close before rename or it may fail during rename
-		if err = file.Close(); err != nil {
-			return InspectError(err, `closing file %q`, file.Name())
+	if err = res.OnProgress(downloadProgress).Consume(func(body io.Reader) error {
+		w := bufio.NewWriter(file)
+		_, err := io.Copy(w, body)
+
+		if err != nil {
+			return InspectError(err, `writing downloaded data to file %q`, file.Name())
 		}
 
-		Echofln("")
+		return w.Flush()
+	}); err != nil {
+		return filepath, InspectError(err, `updating file download progress for %q`, name)
+	}
+
+	// explicitly close before rename or it may fail during rename
+	if err = file.Close(); err != nil {
+		return filepath, InspectError(err, `closing file %q`, file.Name())
+	}
 
-		return InspectError(os.Rename(tmpfile, filepath), `renaming tmplfile %q to %q`, tmpfile, filepath)
-	})
+	Echofln("")
 
-	return filepath, err
+	return filepath, InspectError(os.Rename(tmpfile, filepath), `renaming tmplfile %q to %q`, tmpfile, filepath)
 }
@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Calls check every interval until it reports done or fails, or until the
+// context is done, in which case the context's cause is returned. The first
+// check is immediate.
+func Poll(ctx context.Context, interval time.Duration, check func() (bool, error)) error {
+	if interval <= 0 {
+		return fmt.Errorf(`Invalid poll interval %s; must be positive`, interval)
+	}
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		if done, err := check(); err != nil || done {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		case <-t.C:
+		}
+	}
+}
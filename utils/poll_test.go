@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPollUntilDone(t *testing.T) {
+	as := asserts(t)
+	calls := 0
+
+	as.ok(Poll(context.Background(), time.Millisecond, func() (bool, error) {
+		calls++
+		return 3 == calls, nil
+	}))
+	as.eq(3, calls)
+}
+
+func TestPollStopsOnError(t *testing.T) {
+	as := asserts(t)
+	calls := 0
+
+	as.err(`boom`, Poll(context.Background(), time.Millisecond, func() (bool, error) {
+		calls++
+		return false, errors.New(`boom`)
+	}))
+	as.eq(1, calls)
+}
+
+func TestPollStopsWhenContextIsDone(t *testing.T) {
+	as := asserts(t)
+	ctx, cancel := context.WithCancelCause(context.Background())
+	calls := 0
+
+	as.err(`gave up`, Poll(ctx, time.Millisecond, func() (bool, error) {
+		if calls++; 2 == calls {
+			cancel(errors.New(`gave up`))
+		}
+		return false, nil
+	}))
+	as.eq(2, calls)
+}
+
+func TestPollRejectsNonPositiveInterval(t *testing.T) {
+	as := asserts(t)
+	calls := 0
+	check := func() (bool, error) {
+		calls++
+		return true, nil
+	}
+
+	as.err(`Invalid poll interval 0s; must be positive`, Poll(context.Background(), 0, check))
+	as.err(`Invalid poll interval -1s; must be positive`, Poll(context.Background(), -time.Second, check))
+	as.eq(0, calls)
+}